@@ -0,0 +1,77 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+//go:generate go run ../tools/build_index -in embed -out embed/pokedex.gob
+//go:generate go run ../tools/bundle_assets -in embed -out embed/bundle.bin
+
+var (
+	embedded     *Bundle
+	embeddedErr  error
+	embeddedOnce sync.Once
+)
+
+// embeddedBundle opens the embedded bundle on first use. Builds without
+// realdata get an empty one.
+func embeddedBundle() (*Bundle, error) {
+	embeddedOnce.Do(func() {
+		if len(bundleData) == 0 {
+			embedded = &Bundle{}
+			return
+		}
+		embedded, embeddedErr = OpenBundle(bundleData)
+	})
+	return embedded, embeddedErr
+}
+
+// overrideDir, when set, is searched before the embedded assets.
+var overrideDir string
+
+// SetOverrideDir makes the files under dir take precedence over the
+// embedded ones. The directory mirrors the embedded layout: api_data/,
+// art/ and sprites/.
+func SetOverrideDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s não é um diretório", dir)
+	}
+	overrideDir = dir
+	return nil
+}
+
+// ReadFile reads an asset by its path in the embedded layout, such as
+// "sprites/25.png".
+func ReadFile(name string) ([]byte, error) {
+	if overrideDir != "" {
+		data, err := os.ReadFile(filepath.Join(overrideDir, filepath.FromSlash(name)))
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return data, err
+		}
+	}
+	bundle, err := embeddedBundle()
+	if err != nil {
+		return nil, err
+	}
+	return bundle.ReadFile(name)
+}
+
+// Exists reports whether an asset file or directory is available.
+func Exists(name string) bool {
+	if overrideDir != "" {
+		if _, err := os.Stat(filepath.Join(overrideDir, filepath.FromSlash(name))); err == nil {
+			return true
+		}
+	}
+	bundle, err := embeddedBundle()
+	return err == nil && bundle.Exists(name)
+}
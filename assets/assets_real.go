@@ -2,11 +2,12 @@
 
 package assets
 
-import "embed"
+import _ "embed"
 
-// EmbedFS contains the minified API data and ASCII art for embedding
-// This results in a much smaller binary (~25MB vs ~700MB with all assets)
+// bundleData holds the minified API data, ASCII art and PNG sprites, each
+// compressed on its own (see tools/bundle_assets): about 8MB for ~28MB of
+// files. It is generated, not committed; run go generate ./assets before
+// building with realdata.
 //
-//go:embed embed/api_data/*.json
-//go:embed embed/art/*.ascii
-var EmbedFS embed.FS
+//go:embed embed/bundle.bin
+var bundleData []byte
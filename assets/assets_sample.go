@@ -2,7 +2,5 @@
 
 package assets
 
-import "embed"
-
-// EmbedFS is empty when realdata is not present
-var EmbedFS embed.FS
+// bundleData is empty when realdata is not present
+var bundleData []byte
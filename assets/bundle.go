@@ -0,0 +1,187 @@
+package assets
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// A bundle packs many asset files into one blob: a header, an index of
+// entries and the entry data, each compressed on its own so a single file
+// can be read without inflating the rest.
+//
+// Layout (little-endian):
+//
+//	magic   [8]byte "PKBUNDL1"
+//	count   uint32
+//	count × { nameLen uint16, name, method uint8, offset, size, rawSize uint32 }
+//	data    entries, offsets relative to the start of the data
+const bundleMagic = "PKBUNDL1"
+
+// Compression methods of bundle entries.
+const (
+	MethodStore   = 0 // kept as-is, for data that is already compressed
+	MethodDeflate = 1
+)
+
+// BundleEntry describes one file in a bundle.
+type BundleEntry struct {
+	Name    string
+	Method  uint8
+	Offset  uint32
+	Size    uint32 // stored size
+	RawSize uint32 // size once decompressed
+}
+
+// Bundle reads files out of a bundle blob, decompressing them on demand.
+type Bundle struct {
+	data    []byte
+	entries map[string]BundleEntry
+}
+
+// OpenBundle parses the index of a bundle. The entry data is only touched
+// when a file is read.
+func OpenBundle(blob []byte) (*Bundle, error) {
+	r := bytes.NewReader(blob)
+
+	magic := make([]byte, len(bundleMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != bundleMagic {
+		return nil, errors.New("bundle inválido: cabeçalho desconhecido")
+	}
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("bundle inválido: %w", err)
+	}
+
+	entries := make(map[string]BundleEntry, count)
+	for i := uint32(0); i < count; i++ {
+		entry, err := readEntry(r)
+		if err != nil {
+			return nil, fmt.Errorf("bundle inválido: entrada %d: %w", i, err)
+		}
+		entries[entry.Name] = entry
+	}
+
+	data := blob[len(blob)-r.Len():]
+	for _, entry := range entries {
+		if uint64(entry.Offset)+uint64(entry.Size) > uint64(len(data)) {
+			return nil, fmt.Errorf("bundle inválido: %s fora dos limites", entry.Name)
+		}
+	}
+
+	return &Bundle{data: data, entries: entries}, nil
+}
+
+func readEntry(r io.Reader) (BundleEntry, error) {
+	var nameLen uint16
+	if err := binary.Read(r, binary.LittleEndian, &nameLen); err != nil {
+		return BundleEntry{}, err
+	}
+	name := make([]byte, nameLen)
+	if _, err := io.ReadFull(r, name); err != nil {
+		return BundleEntry{}, err
+	}
+
+	entry := BundleEntry{Name: string(name)}
+	for _, field := range []any{&entry.Method, &entry.Offset, &entry.Size, &entry.RawSize} {
+		if err := binary.Read(r, binary.LittleEndian, field); err != nil {
+			return BundleEntry{}, err
+		}
+	}
+	return entry, nil
+}
+
+// ReadFile returns the decompressed contents of the named file.
+func (b *Bundle) ReadFile(name string) ([]byte, error) {
+	entry, ok := b.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	stored := b.data[entry.Offset : entry.Offset+entry.Size]
+
+	switch entry.Method {
+	case MethodStore:
+		return bytes.Clone(stored), nil
+	case MethodDeflate:
+		out := make([]byte, 0, entry.RawSize)
+		buf := bytes.NewBuffer(out)
+		if _, err := io.Copy(buf, flate.NewReader(bytes.NewReader(stored))); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("%s: método de compressão desconhecido %d", name, entry.Method)
+	}
+}
+
+// Exists reports whether the bundle holds the named file, or files under
+// the named directory.
+func (b *Bundle) Exists(name string) bool {
+	if _, ok := b.entries[name]; ok {
+		return true
+	}
+	prefix := strings.TrimSuffix(name, "/") + "/"
+	for entryName := range b.entries {
+		if strings.HasPrefix(entryName, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteBundle writes files, in the given order, as a bundle. Each file is
+// deflated unless that does not make it smaller.
+func WriteBundle(w io.Writer, names []string, files map[string][]byte) error {
+	var data bytes.Buffer
+	entries := make([]BundleEntry, 0, len(names))
+
+	for _, name := range names {
+		raw := files[name]
+		entry := BundleEntry{Name: name, Method: MethodStore, Offset: uint32(data.Len()), RawSize: uint32(len(raw))}
+
+		var compressed bytes.Buffer
+		fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(raw); err != nil {
+			return err
+		}
+		if err := fw.Close(); err != nil {
+			return err
+		}
+
+		stored := raw
+		if compressed.Len() < len(raw) {
+			entry.Method = MethodDeflate
+			stored = compressed.Bytes()
+		}
+		entry.Size = uint32(len(stored))
+		data.Write(stored)
+		entries = append(entries, entry)
+	}
+
+	var header bytes.Buffer
+	header.WriteString(bundleMagic)
+	binary.Write(&header, binary.LittleEndian, uint32(len(entries)))
+	for _, entry := range entries {
+		binary.Write(&header, binary.LittleEndian, uint16(len(entry.Name)))
+		header.WriteString(entry.Name)
+		binary.Write(&header, binary.LittleEndian, entry.Method)
+		binary.Write(&header, binary.LittleEndian, entry.Offset)
+		binary.Write(&header, binary.LittleEndian, entry.Size)
+		binary.Write(&header, binary.LittleEndian, entry.RawSize)
+	}
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	_, err := w.Write(data.Bytes())
+	return err
+}
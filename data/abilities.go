@@ -0,0 +1,111 @@
+package data
+
+import (
+	"charm-pokemon/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+type abilityAPIResponse struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []struct {
+		Name     string      `json:"name"`
+		Language apiLanguage `json:"language"`
+	} `json:"names"`
+	EffectEntries []struct {
+		ShortEffect string      `json:"short_effect"`
+		Language    apiLanguage `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText string      `json:"flavor_text"`
+		Language   apiLanguage `json:"language"`
+	} `json:"flavor_text_entries"`
+}
+
+type apiLanguage struct {
+	Name string `json:"name"`
+}
+
+// isPortuguese reports whether a PokeAPI language code is one the app can
+// show as Portuguese text.
+func isPortuguese(lang string) bool {
+	return lang == "pt" || lang == "pt-BR"
+}
+
+// parseAbilities lists the abilities of an entry and records the number of
+// each one's catalog file in ids.
+func parseAbilities(resp pokeAPIResponse, ids map[string]int) []models.PokemonAbility {
+	var abilities []models.PokemonAbility
+	for _, a := range resp.Abilities {
+		abilities = append(abilities, models.PokemonAbility{Key: a.Ability.Name, Hidden: a.IsHidden})
+		if id := idFromURL(a.Ability.URL); id > 0 {
+			ids[a.Ability.Name] = id
+		}
+	}
+	return abilities
+}
+
+// loadAbilities reads the catalog entries of the abilities in ids. An
+// ability whose file is missing is left out of the catalog, where the app
+// falls back to its key; Validate reports it, as it does an empty catalog.
+func loadAbilities(readFile func(name string) ([]byte, error), pokedex *models.Pokedex, ids map[string]int, problems *LoadError) {
+	keys := make([]string, 0, len(ids))
+	for key := range ids {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := fmt.Sprintf("api_data/ability_%d.json", ids[key])
+		data, err := readFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			problems.add(name, err)
+			continue
+		}
+		var resp abilityAPIResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			problems.add(name, err)
+			continue
+		}
+
+		ability := &models.Ability{ID: resp.ID, Key: resp.Name}
+		for _, n := range resp.Names {
+			switch {
+			case n.Language.Name == "en":
+				ability.NameEN = n.Name
+			case isPortuguese(n.Language.Name):
+				ability.NamePT = n.Name
+			}
+		}
+		for _, e := range resp.EffectEntries {
+			switch {
+			case e.Language.Name == "en":
+				ability.EffectEN = oneLine(e.ShortEffect)
+			case isPortuguese(e.Language.Name):
+				ability.EffectPT = oneLine(e.ShortEffect)
+			}
+		}
+		// Few abilities have a Portuguese effect; the newest game text will do
+		if ability.EffectPT == "" {
+			for _, f := range resp.FlavorTextEntries {
+				if isPortuguese(f.Language.Name) {
+					ability.EffectPT = oneLine(f.FlavorText)
+				}
+			}
+		}
+		pokedex.AddAbility(ability)
+	}
+}
+
+// oneLine joins game text, which is broken to fit the games' text boxes.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
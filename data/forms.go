@@ -0,0 +1,104 @@
+package data
+
+import (
+	"charm-pokemon/models"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// regionNames are the form suffixes of regional variants.
+var regionNames = map[string]string{
+	"alola":  "Alola",
+	"galar":  "Galar",
+	"hisui":  "Hisui",
+	"paldea": "Paldea",
+}
+
+// loadForms reads the entries of the alternate forms a species lists.
+func loadForms(readFile func(name string) ([]byte, error), pokemon *models.Pokemon, species *speciesAPIResponse, abilityIDs map[string]int, problems *LoadError) {
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			continue
+		}
+		id := idFromURL(variety.Pokemon.URL)
+		name := fmt.Sprintf("api_data/pokemon_%d.json", id)
+		formData, err := readFile(name)
+		if err != nil {
+			problems.add(name, err)
+			continue
+		}
+		var resp pokeAPIResponse
+		if err := json.Unmarshal(formData, &resp); err != nil {
+			problems.add(name, err)
+			continue
+		}
+
+		form := &models.Form{
+			ID:     resp.ID,
+			Key:    resp.Name,
+			Types:  translateTypes(resp),
+			Height: float64(resp.Height),
+			Weight: float64(resp.Weight),
+			Stats:  parseStats(resp),
+
+			EVYield:   parseEVYield(resp),
+			Abilities: parseAbilities(resp, abilityIDs),
+		}
+		form.NamePT, form.NameEN, form.Kind = formNames(pokemon, species.Name, resp.Name)
+		if form.Kind == models.FormOther && form.Stats == pokemon.Stats && sameTypes(form.Types, pokemon.Types) {
+			form.Kind = models.FormCosmetic
+		}
+		pokemon.Forms = append(pokemon.Forms, form)
+	}
+}
+
+// formNames names a form after its species from the suffix PokeAPI adds to
+// the species name, e.g. "raichu-alola" or "charizard-mega-x".
+func formNames(pokemon *models.Pokemon, speciesKey, formKey string) (pt, en string, kind models.FormKind) {
+	suffix := strings.TrimPrefix(formKey, speciesKey+"-")
+	parts := strings.Split(suffix, "-")
+	rest := strings.Join(parts[1:], " ")
+
+	switch {
+	case regionNames[parts[0]] != "":
+		region := regionNames[parts[0]]
+		pt = fmt.Sprintf("%s de %s", pokemon.NamePT, region)
+		en = fmt.Sprintf("%s (%s)", pokemon.NameEN, region)
+		if rest != "" {
+			pt += " (" + strings.Title(rest) + ")"
+			en = fmt.Sprintf("%s (%s %s)", pokemon.NameEN, region, strings.Title(rest))
+		}
+		return pt, en, models.FormRegional
+	case parts[0] == "mega":
+		pt = strings.TrimSpace(fmt.Sprintf("Mega %s %s", pokemon.NamePT, strings.ToUpper(rest)))
+		en = strings.TrimSpace(fmt.Sprintf("Mega %s %s", pokemon.NameEN, strings.ToUpper(rest)))
+		return pt, en, models.FormMega
+	case suffix == "gmax":
+		return pokemon.NamePT + " Gigantamax", "Gigantamax " + pokemon.NameEN, models.FormGigantamax
+	}
+
+	label := strings.Title(strings.ReplaceAll(suffix, "-", " "))
+	return fmt.Sprintf("%s (%s)", pokemon.NamePT, label), fmt.Sprintf("%s (%s)", pokemon.NameEN, label), models.FormOther
+}
+
+func sameTypes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// idFromURL extracts the number that ends a PokeAPI resource URL such as
+// https://pokeapi.co/api/v2/pokemon-species/25/.
+func idFromURL(url string) int {
+	parts := strings.Split(strings.Trim(url, "/"), "/")
+	var id int
+	fmt.Sscanf(parts[len(parts)-1], "%d", &id)
+	return id
+}
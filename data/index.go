@@ -0,0 +1,70 @@
+package data
+
+import (
+	"bytes"
+	"charm-pokemon/models"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// IndexFile is the asset holding the precomputed Pokedex.
+const IndexFile = "pokedex.gob"
+
+// IndexVersion changes whenever the layout of models.Pokemon does, so an
+// index from another version is ignored rather than half-decoded.
+const IndexVersion = 9
+
+// pokedexIndex is the gob-encoded form of a Pokedex. The lookup maps are
+// rebuilt on load, which is cheaper than storing them.
+type pokedexIndex struct {
+	Version   int
+	Pokemon   []*models.Pokemon
+	Abilities []*models.Ability
+	Moves     []*models.MoveInfo
+	Dexes     []*models.RegionalDex
+}
+
+// WriteIndex writes the Pokedex as a versioned index.
+func WriteIndex(w io.Writer, pokedex *models.Pokedex) error {
+	index := pokedexIndex{
+		Version: IndexVersion,
+		Pokemon: pokedex.Pokemon,
+		Dexes:   pokedex.RegionalDexes,
+	}
+	for _, key := range pokedex.AbilityKeys() {
+		if ability := pokedex.GetAbility(key); ability != nil {
+			index.Abilities = append(index.Abilities, ability)
+		}
+	}
+	for _, key := range pokedex.MoveKeys() {
+		index.Moves = append(index.Moves, pokedex.GetMove(key))
+	}
+	return gob.NewEncoder(w).Encode(index)
+}
+
+// ReadIndex decodes an index written by WriteIndex.
+func ReadIndex(blob []byte) (*models.Pokedex, error) {
+	var index pokedexIndex
+	if err := gob.NewDecoder(bytes.NewReader(blob)).Decode(&index); err != nil {
+		return nil, fmt.Errorf("índice inválido: %w", err)
+	}
+	if index.Version != IndexVersion {
+		return nil, fmt.Errorf("índice na versão %d, esperada %d", index.Version, IndexVersion)
+	}
+
+	pokedex := models.NewPokedex()
+	for _, pokemon := range index.Pokemon {
+		pokedex.AddPokemon(pokemon)
+	}
+	for _, ability := range index.Abilities {
+		pokedex.AddAbility(ability)
+	}
+	for _, move := range index.Moves {
+		pokedex.AddMove(move)
+	}
+	for _, dex := range index.Dexes {
+		pokedex.AddRegionalDex(dex)
+	}
+	return pokedex, nil
+}
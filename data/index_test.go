@@ -0,0 +1,107 @@
+package data
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"charm-pokemon/models"
+)
+
+// embedDir holds the minified data the realdata index is built from.
+var embedDir = filepath.Join("..", "assets", "embed")
+
+// cachedReader reads the files of dir once, so loaders timed with it
+// compare parsing only.
+func cachedReader(dir string) func(name string) ([]byte, error) {
+	files := make(map[string][]byte)
+	return func(name string) ([]byte, error) {
+		if data, ok := files[name]; ok {
+			return data, nil
+		}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err == nil {
+			files[name] = data
+		}
+		return data, err
+	}
+}
+
+func TestIndexRoundTrip(t *testing.T) {
+	pokedex := models.NewPokedex()
+	pokemon := &models.Pokemon{
+		ID:         25,
+		NamePT:     "Pikachu",
+		NameEN:     "Pikachu",
+		Generation: 1,
+		Types:      []string{"elétrico"},
+		Stats:      models.PokemonStats{HP: 35, Attack: 55, Defense: 40, SpAtk: 50, SpDef: 50, Speed: 90},
+		Species:    &models.Species{EggGroups: []string{"ground", "fairy"}, GenderRate: 4},
+	}
+	pokedex.AddPokemon(pokemon)
+
+	var buf bytes.Buffer
+	if err := WriteIndex(&buf, pokedex); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadIndex(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := read.GetByID(25)
+	if got == nil {
+		t.Fatal("Pikachu missing from the index")
+	}
+	if got.NameEN != "Pikachu" || got.Stats != pokemon.Stats || got.Species == nil || got.Species.GenderRate != 4 {
+		t.Errorf("read back %+v, want %+v", got, pokemon)
+	}
+	if n := len(read.GetPokemonByType("elétrico")); n != 1 {
+		t.Errorf("%d electric Pokemon after reading the index, want 1", n)
+	}
+	if n := len(read.GetPokemonByEggGroup("fairy")); n != 1 {
+		t.Errorf("%d Pokemon in the fairy egg group after reading the index, want 1", n)
+	}
+}
+
+func TestReadIndexTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteIndex(&buf, models.NewPokedex()); err != nil {
+		t.Fatal(err)
+	}
+	blob := buf.Bytes()
+	if _, err := ReadIndex(blob[:len(blob)/2]); err == nil {
+		t.Error("ReadIndex accepted a truncated index")
+	}
+}
+
+// BenchmarkLoadPokedex compares building the Pokedex from the minified JSON
+// with decoding the index, both from memory:
+//
+//	go test ./data -run '^$' -bench LoadPokedex
+func BenchmarkLoadPokedex(b *testing.B) {
+	if _, err := os.Stat(filepath.Join(embedDir, "api_data")); err != nil {
+		b.Skipf("no minified data: %v", err)
+	}
+	readFile := cachedReader(embedDir)
+	pokedex, _ := LoadPokedexJSON(readFile, nil)
+
+	var index bytes.Buffer
+	if err := WriteIndex(&index, pokedex); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			LoadPokedexJSON(readFile, nil)
+		}
+	})
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ReadIndex(index.Bytes()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
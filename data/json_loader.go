@@ -0,0 +1,220 @@
+package data
+
+import (
+	"charm-pokemon/models"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type pokeAPIResponse struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Height int    `json:"height"`
+	Weight int    `json:"weight"`
+	Stats  []struct {
+		BaseStat int `json:"base_stat"`
+		Effort   int `json:"effort"`
+		Stat     struct {
+			Name string `json:"name"`
+		} `json:"stat"`
+	} `json:"stats"`
+	Types []struct {
+		Type struct {
+			Name string `json:"name"`
+		} `json:"type"`
+	} `json:"types"`
+	Abilities []struct {
+		IsHidden bool `json:"is_hidden"`
+		Ability  struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"ability"`
+	} `json:"abilities"`
+}
+
+type genAPIResponse struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	PokemonSpecies []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"pokemon_species"`
+}
+
+// LoadPokedexJSON builds the Pokedex from the minified PokeAPI JSON.
+// readFile resolves paths such as "api_data/pokemon_25.json", so the same
+// loader serves the embedded assets and the build tools. Files that cannot
+// be read or parsed are skipped and reported in a *LoadError.
+func LoadPokedexJSON(readFile func(name string) ([]byte, error), progress ProgressFunc) (*models.Pokedex, error) {
+	pokedex := models.NewPokedex()
+	problems := &LoadError{}
+
+	const generations, pokemonCount = 9, 1025
+	total := generations + pokemonCount
+
+	// 1. Load all generations to map pokemon to generations
+	pokemonToGen := make(map[int]int)
+	for i := 1; i <= generations; i++ {
+		progress.report(i, total)
+
+		name := fmt.Sprintf("api_data/generation_%d.json", i)
+		genData, err := readFile(name)
+		if err != nil {
+			problems.add(name, err)
+			continue
+		}
+		var genResponse genAPIResponse
+		if err := json.Unmarshal(genData, &genResponse); err != nil {
+			problems.add(name, err)
+			continue
+		}
+		for _, species := range genResponse.PokemonSpecies {
+			if id := idFromURL(species.URL); id > 0 {
+				pokemonToGen[id] = i
+			}
+		}
+	}
+
+	// 2. Load all pokemon data
+	abilityIDs := make(map[string]int)
+	for i := 1; i <= pokemonCount; i++ {
+		progress.report(generations+i, total)
+
+		name := fmt.Sprintf("api_data/pokemon_%d.json", i)
+		pokemonData, err := readFile(name)
+		if err != nil {
+			problems.add(name, err)
+			continue
+		}
+
+		var resp pokeAPIResponse
+		if err := json.Unmarshal(pokemonData, &resp); err != nil {
+			problems.add(name, err)
+			continue
+		}
+
+		pokemon := &models.Pokemon{
+			ID:     resp.ID,
+			NameEN: strings.Title(resp.Name),
+			NamePT: strings.Title(resp.Name), // Fallback to English
+			Height: float64(resp.Height),
+			Weight: float64(resp.Weight),
+		}
+
+		pokemon.Generation = pokemonToGen[pokemon.ID]
+		if pokemon.Generation == 0 {
+			// Heuristic if generator mapping failed
+			if pokemon.ID <= 151 {
+				pokemon.Generation = 1
+			} else if pokemon.ID <= 251 {
+				pokemon.Generation = 2
+			} else if pokemon.ID <= 386 {
+				pokemon.Generation = 3
+			} else if pokemon.ID <= 493 {
+				pokemon.Generation = 4
+			} else if pokemon.ID <= 649 {
+				pokemon.Generation = 5
+			} else if pokemon.ID <= 721 {
+				pokemon.Generation = 6
+			} else if pokemon.ID <= 809 {
+				pokemon.Generation = 7
+			} else if pokemon.ID <= 905 {
+				pokemon.Generation = 8
+			} else {
+				pokemon.Generation = 9
+			}
+		}
+
+		pokemon.Stats = parseStats(resp)
+		pokemon.EVYield = parseEVYield(resp)
+		pokemon.Types = translateTypes(resp)
+		pokemon.Abilities = parseAbilities(resp, abilityIDs)
+		loadSpecies(readFile, pokemon, abilityIDs, problems)
+
+		pokedex.AddPokemon(pokemon)
+	}
+
+	// 3. Describe the abilities the Pokemon have
+	loadAbilities(readFile, pokedex, abilityIDs, problems)
+
+	// 4. The move catalog
+	loadMoves(readFile, pokedex, problems)
+
+	// 5. Regional dexes, once every Pokemon they number is known
+	loadRegionalDexes(readFile, pokedex, problems)
+
+	return pokedex, problems.err()
+}
+
+func parseStats(resp pokeAPIResponse) models.PokemonStats {
+	var stats models.PokemonStats
+	for _, s := range resp.Stats {
+		setStat(&stats, s.Stat.Name, s.BaseStat)
+	}
+	return stats
+}
+
+// parseEVYield reads the effort values defeating the Pokemon gives.
+func parseEVYield(resp pokeAPIResponse) models.PokemonStats {
+	var yield models.PokemonStats
+	for _, s := range resp.Stats {
+		setStat(&yield, s.Stat.Name, s.Effort)
+	}
+	return yield
+}
+
+func setStat(stats *models.PokemonStats, name string, val int) {
+	switch name {
+	case "hp":
+		stats.HP = val
+	case "attack":
+		stats.Attack = val
+	case "defense":
+		stats.Defense = val
+	case "special-attack":
+		stats.SpAtk = val
+	case "special-defense":
+		stats.SpDef = val
+	case "speed":
+		stats.Speed = val
+	}
+}
+
+func translateTypes(resp pokeAPIResponse) []string {
+	var types []string
+	for _, t := range resp.Types {
+		types = append(types, translateType(t.Type.Name))
+	}
+	return types
+}
+
+// typeNamesPT maps the PokeAPI type names to the Portuguese ones used
+// throughout the app.
+var typeNamesPT = map[string]string{
+	"normal":   "normal",
+	"fire":     "fogo",
+	"water":    "água",
+	"grass":    "erva",
+	"electric": "elétrico",
+	"ice":      "gelo",
+	"fighting": "lutador",
+	"poison":   "veneno",
+	"ground":   "terra",
+	"flying":   "voador",
+	"psychic":  "psíquico",
+	"bug":      "inseto",
+	"rock":     "pedra",
+	"ghost":    "fantasma",
+	"dragon":   "dragão",
+	"dark":     "sombrio",
+	"steel":    "metálico",
+	"fairy":    "fada",
+}
+
+func translateType(t string) string {
+	if pt, ok := typeNamesPT[t]; ok {
+		return pt
+	}
+	return t
+}
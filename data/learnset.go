@@ -0,0 +1,54 @@
+package data
+
+import (
+	"charm-pokemon/models"
+	"encoding/json"
+	"fmt"
+)
+
+// learnsetJSON is the compact learnset written by minify_data: moves,
+// methods and version groups are listed once and entries refer to them by
+// index.
+type learnsetJSON struct {
+	VersionGroups []string `json:"version_groups"`
+	Methods       []string `json:"methods"`
+	Moves         []string `json:"moves"`
+	Entries       [][4]int `json:"entries"` // move, method, level, version group
+}
+
+// learnsetFile is the asset holding the learnset of a Pokemon or form.
+func learnsetFile(id int) string {
+	return fmt.Sprintf("learnsets/%d.json", id)
+}
+
+// ParseLearnset decodes a learnset file. Learnsets are kept out of the
+// Pokedex and read when a screen needs one.
+func ParseLearnset(blob []byte) (*models.Learnset, error) {
+	var raw learnsetJSON
+	if err := json.Unmarshal(blob, &raw); err != nil {
+		return nil, err
+	}
+
+	learnset := &models.Learnset{
+		VersionGroups: raw.VersionGroups,
+		Moves:         make(map[string][]models.LearnedMove),
+	}
+	for _, e := range raw.Entries {
+		move, method, level, group := e[0], e[1], e[2], e[3]
+		if move < 0 || method < 0 || group < 0 ||
+			move >= len(raw.Moves) || method >= len(raw.Methods) || group >= len(raw.VersionGroups) {
+			return nil, fmt.Errorf("entrada fora dos limites: %v", e)
+		}
+		learnMethod, ok := models.ParseLearnMethod(raw.Methods[method])
+		if !ok {
+			continue
+		}
+		versionGroup := raw.VersionGroups[group]
+		learnset.Moves[versionGroup] = append(learnset.Moves[versionGroup], models.LearnedMove{
+			Move:   raw.Moves[move],
+			Method: learnMethod,
+			Level:  level,
+		})
+	}
+	return learnset, nil
+}
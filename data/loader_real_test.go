@@ -0,0 +1,62 @@
+//go:build realdata
+
+package data
+
+import (
+	"testing"
+
+	"charm-pokemon/assets"
+)
+
+// TestEmbeddedFeatureData checks that the embedded bundle carries the data
+// behind forms, abilities, breeding, regional dexes and learnsets, which a
+// bundle built from older api_data silently lacks.
+func TestEmbeddedFeatureData(t *testing.T) {
+	// Old api_data fails every check below; say why once instead
+	if !assets.Exists(speciesFile(1)) {
+		t.Fatalf("the bundle has no %s: regenerate assets/embed with `go run ./tools/pipeline all`", speciesFile(1))
+	}
+
+	pokedex, err := LoadPokedex(nil)
+	if err != nil {
+		t.Fatalf("LoadPokedex: %v", err)
+	}
+
+	if charizard := pokedex.GetByID(6); charizard == nil || len(charizard.Forms) == 0 {
+		t.Error("Charizard has no forms")
+	}
+
+	pikachu := pokedex.GetByID(25)
+	if pikachu == nil {
+		t.Fatal("no Pikachu")
+	}
+	if pokedex.GetAbility("static") == nil {
+		t.Error("the ability catalog has no Static")
+	}
+	if len(pokedex.GetPokemonByAbility("static")) == 0 {
+		t.Error("no Pokemon has Static")
+	}
+	if pikachu.Species == nil || len(pikachu.Species.EggGroups) == 0 {
+		t.Error("Pikachu has no breeding data")
+	}
+	if dex := pokedex.GetRegionalDex("kanto"); dex == nil || len(dex.Entries) == 0 {
+		t.Error("no Kanto regional dex")
+	}
+	if move := pokedex.GetMove("thunderbolt"); move == nil || len(pokedex.GetPokemonByMove("thunderbolt")) == 0 {
+		t.Error("no Pokemon learns Thunderbolt")
+	}
+
+	learnset, err := LoadLearnset(25)
+	if err != nil {
+		t.Fatalf("LoadLearnset(25): %v", err)
+	}
+	found := false
+	for _, moves := range learnset.Moves {
+		for _, move := range moves {
+			found = found || move.Move == "thunderbolt"
+		}
+	}
+	if !found {
+		t.Error("Pikachu's learnset has no Thunderbolt")
+	}
+}
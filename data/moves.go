@@ -0,0 +1,54 @@
+package data
+
+import (
+	"charm-pokemon/models"
+	"encoding/json"
+	"errors"
+	"io/fs"
+)
+
+// MoveCatalogFile is the move catalog written by clean_data.
+const MoveCatalogFile = "api_data/moves.json"
+
+type moveCatalogJSON []struct {
+	Name        string `json:"name"`
+	NameEN      string `json:"name_en"`
+	NamePT      string `json:"name_pt"`
+	Type        string `json:"type"`
+	DamageClass string `json:"damage_class"`
+	Power       int    `json:"power"`
+	Accuracy    int    `json:"accuracy"`
+	PP          int    `json:"pp"`
+	LearnedBy   []int  `json:"learned_by"`
+}
+
+// loadMoves reads the move catalog. Data built before the catalog existed
+// has none, which leaves the moves screen empty.
+func loadMoves(readFile func(name string) ([]byte, error), pokedex *models.Pokedex, problems *LoadError) {
+	data, err := readFile(MoveCatalogFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		problems.add(MoveCatalogFile, err)
+		return
+	}
+	var catalog moveCatalogJSON
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		problems.add(MoveCatalogFile, err)
+		return
+	}
+	for _, m := range catalog {
+		pokedex.AddMove(&models.MoveInfo{
+			Key:       m.Name,
+			NamePT:    m.NamePT,
+			NameEN:    m.NameEN,
+			Type:      translateType(m.Type),
+			Category:  m.DamageClass,
+			Power:     m.Power,
+			Accuracy:  m.Accuracy,
+			PP:        m.PP,
+			LearnedBy: m.LearnedBy,
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Progress reports how far loading the Pokedex got.
+type Progress struct {
+	Done  int
+	Total int
+}
+
+// Percent returns the progress from 0 to 100.
+func (p Progress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// ProgressFunc receives progress updates while loading. It may be nil.
+type ProgressFunc func(Progress)
+
+func (f ProgressFunc) report(done, total int) {
+	if f != nil {
+		f(Progress{Done: done, Total: total})
+	}
+}
+
+// LoadError lists the files that could not be loaded. The Pokedex returned
+// alongside it holds everything else.
+type LoadError struct {
+	Problems []error
+}
+
+func (e *LoadError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d ficheiros não carregados", len(e.Problems))
+	for i, problem := range e.Problems {
+		if i == 3 {
+			fmt.Fprintf(&b, "\n  ... e mais %d", len(e.Problems)-i)
+			break
+		}
+		fmt.Fprintf(&b, "\n  %v", problem)
+	}
+	return b.String()
+}
+
+// add records a problem with a file.
+func (e *LoadError) add(name string, err error) {
+	e.Problems = append(e.Problems, fmt.Errorf("%s: %w", name, err))
+}
+
+// err returns nil when there were no problems.
+func (e *LoadError) err() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,74 @@
+package data
+
+import (
+	"charm-pokemon/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// maxPokedexID is the highest PokeAPI pokedex the loader looks for. Gaps in
+// the numbering are skipped; Validate reports data with no dexes at all.
+const maxPokedexID = 40
+
+type pokedexAPIResponse struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	IsMainSeries bool   `json:"is_main_series"`
+	Names        []struct {
+		Name     string      `json:"name"`
+		Language apiLanguage `json:"language"`
+	} `json:"names"`
+	Region         *apiResource `json:"region"`
+	PokemonEntries []struct {
+		EntryNumber    int `json:"entry_number"`
+		PokemonSpecies struct {
+			URL string `json:"url"`
+		} `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+// loadRegionalDexes reads the dexes of the main series games. The national
+// dex is the Pokedex itself and is left out.
+func loadRegionalDexes(readFile func(name string) ([]byte, error), pokedex *models.Pokedex, problems *LoadError) {
+	for id := 2; id <= maxPokedexID; id++ {
+		name := fmt.Sprintf("api_data/pokedex_%d.json", id)
+		data, err := readFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			problems.add(name, err)
+			continue
+		}
+		var resp pokedexAPIResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			problems.add(name, err)
+			continue
+		}
+		if !resp.IsMainSeries || resp.Name == "national" {
+			continue
+		}
+
+		dex := &models.RegionalDex{
+			ID:     resp.ID,
+			Key:    resp.Name,
+			Region: resourceName(resp.Region),
+		}
+		for _, n := range resp.Names {
+			switch {
+			case n.Language.Name == "en":
+				dex.NameEN = n.Name
+			case isPortuguese(n.Language.Name):
+				dex.NamePT = n.Name
+			}
+		}
+		for _, entry := range resp.PokemonEntries {
+			if id := idFromURL(entry.PokemonSpecies.URL); id > 0 {
+				dex.Entries = append(dex.Entries, models.DexEntry{Number: entry.EntryNumber, PokemonID: id})
+			}
+		}
+		pokedex.AddRegionalDex(dex)
+	}
+}
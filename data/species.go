@@ -0,0 +1,104 @@
+package data
+
+import (
+	"charm-pokemon/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+type speciesAPIResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Varieties []struct {
+		IsDefault bool `json:"is_default"`
+		Pokemon   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"varieties"`
+	EggGroups     []apiResource `json:"egg_groups"`
+	GenderRate    int           `json:"gender_rate"`
+	CaptureRate   int           `json:"capture_rate"`
+	BaseHappiness *int          `json:"base_happiness"`
+	GrowthRate    *apiResource  `json:"growth_rate"`
+	HatchCounter  *int          `json:"hatch_counter"`
+	Habitat       *apiResource  `json:"habitat"`
+	Color         *apiResource  `json:"color"`
+	IsLegendary   bool          `json:"is_legendary"`
+	IsMythical    bool          `json:"is_mythical"`
+
+	EvolvesFromSpecies *apiResource `json:"evolves_from_species"`
+}
+
+// apiResource is a named link to another PokeAPI resource, of which only the
+// name is used.
+type apiResource struct {
+	Name string `json:"name"`
+}
+
+// speciesFile is the asset holding the species of a Pokemon.
+func speciesFile(id int) string {
+	return fmt.Sprintf("api_data/pokemon-species_%d.json", id)
+}
+
+// loadSpecies reads the species file of pokemon for its breeding data and
+// alternate forms. Without the file the Pokemon has neither, which Validate
+// reports.
+func loadSpecies(readFile func(name string) ([]byte, error), pokemon *models.Pokemon, abilityIDs map[string]int, problems *LoadError) {
+	name := speciesFile(pokemon.ID)
+	speciesData, err := readFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		problems.add(name, err)
+		return
+	}
+	var species speciesAPIResponse
+	if err := json.Unmarshal(speciesData, &species); err != nil {
+		problems.add(name, err)
+		return
+	}
+
+	pokemon.Species = parseSpecies(species)
+	loadForms(readFile, pokemon, &species, abilityIDs, problems)
+}
+
+// parseSpecies keeps the breeding data of a species. Species files minified
+// before it was kept have no egg groups, and give nil.
+func parseSpecies(resp speciesAPIResponse) *models.Species {
+	if len(resp.EggGroups) == 0 {
+		return nil
+	}
+	species := &models.Species{
+		Name:          resp.Name,
+		GenderRate:    resp.GenderRate,
+		CaptureRate:   resp.CaptureRate,
+		BaseHappiness: -1,
+		GrowthRate:    resourceName(resp.GrowthRate),
+		Habitat:       resourceName(resp.Habitat),
+		Color:         resourceName(resp.Color),
+		Legendary:     resp.IsLegendary,
+		Mythical:      resp.IsMythical,
+		EvolvesFrom:   resourceName(resp.EvolvesFromSpecies),
+	}
+	for _, group := range resp.EggGroups {
+		species.EggGroups = append(species.EggGroups, group.Name)
+	}
+	if resp.BaseHappiness != nil {
+		species.BaseHappiness = *resp.BaseHappiness
+	}
+	if resp.HatchCounter != nil {
+		species.HatchCounter = *resp.HatchCounter
+	}
+	return species
+}
+
+func resourceName(r *apiResource) string {
+	if r == nil {
+		return ""
+	}
+	return r.Name
+}
@@ -0,0 +1,234 @@
+package data
+
+import (
+	"charm-pokemon/assets"
+	"charm-pokemon/models"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Issue is a problem found with one Pokemon, or with one move of the
+// catalog when Move is set.
+type Issue struct {
+	ID      int
+	Name    string
+	Move    string
+	Problem string
+}
+
+// ValidationReport is the outcome of Validate.
+type ValidationReport struct {
+	Checked  int
+	Issues   []Issue
+	LoadErr  error // files the loader had to skip
+	Expected int
+	Moves    int // moves of the catalog checked
+}
+
+// OK reports whether the data is complete.
+func (r ValidationReport) OK() bool {
+	return len(r.Issues) == 0 && r.LoadErr == nil
+}
+
+// Write prints the report in a human-readable form.
+func (r ValidationReport) Write(w io.Writer) {
+	if r.LoadErr != nil {
+		fmt.Fprintf(w, "Erros de carregamento:\n%v\n\n", r.LoadErr)
+	}
+	for _, issue := range r.Issues {
+		if issue.Move != "" {
+			fmt.Fprintf(w, "movimento %s: %s\n", issue.Move, issue.Problem)
+		} else if issue.Name != "" {
+			fmt.Fprintf(w, "#%d %s: %s\n", issue.ID, issue.Name, issue.Problem)
+		} else if issue.ID != 0 {
+			fmt.Fprintf(w, "#%d: %s\n", issue.ID, issue.Problem)
+		} else {
+			fmt.Fprintln(w, issue.Problem)
+		}
+	}
+	if len(r.Issues) > 0 {
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "Validados %d de %d Pokémon e %d movimentos: %d problemas\n", r.Checked, r.Expected, r.Moves, len(r.Issues))
+}
+
+// moveCategories lists the damage classes a move can have.
+var moveCategories = map[string]bool{"physical": true, "special": true, "status": true}
+
+// Validate checks that every expected Pokemon is present and complete:
+// standard and shiny art, six stats, one or two known types, a generation,
+// abilities of the catalog, breeding data and signature moves found in the
+// move catalog. The catalog itself must resolve: every move needs a name, a
+// known type and category, and Pokemon that learn it. The species data must
+// also say which species evolve from which, neither the ability catalog nor
+// the regional dexes can be empty, and some Pokemon must have a learnset.
+// loadErr is the error returned by
+// the loader, if any.
+func Validate(pokedex *models.Pokedex, loadErr error) ValidationReport {
+	return validate(pokedex, loadErr, assets.Exists)
+}
+
+// validate is Validate looking up sprites and baked art with exists.
+func validate(pokedex *models.Pokedex, loadErr error, exists func(path string) bool) ValidationReport {
+	report := ValidationReport{LoadErr: loadErr, Expected: len(expectedIDs())}
+
+	knownTypes := make(map[string]bool, len(typeNamesPT))
+	for _, name := range typeNamesPT {
+		knownTypes[name] = true
+	}
+
+	catalogMoves := make(map[string]bool, len(pokedex.Moves))
+	for _, key := range pokedex.MoveKeys() {
+		move := pokedex.GetMove(key)
+		report.Moves++
+		catalogMoves[strings.ToLower(move.NameEN)] = true
+
+		problem := func(format string, args ...any) {
+			report.Issues = append(report.Issues, Issue{Move: key, Problem: fmt.Sprintf(format, args...)})
+		}
+		if move.NameEN == "" {
+			problem("sem nome")
+		}
+		if !knownTypes[move.Type] {
+			problem("tipo desconhecido %q", move.Type)
+		}
+		if !moveCategories[move.Category] {
+			problem("categoria desconhecida %q", move.Category)
+		}
+		if len(pokedex.GetPokemonByMove(key)) == 0 {
+			problem("nenhum Pokémon o aprende")
+		}
+	}
+	if report.Moves == 0 {
+		report.Issues = append(report.Issues, Issue{Problem: "catálogo de movimentos vazio"})
+	}
+	if !pokedex.HasEvolutionData() {
+		report.Issues = append(report.Issues, Issue{Problem: "nenhuma espécie indica de qual evolui"})
+	}
+	if len(pokedex.Abilities) == 0 {
+		report.Issues = append(report.Issues, Issue{Problem: "catálogo de habilidades vazio: faltam os ficheiros api_data/ability_*.json"})
+	}
+	if len(pokedex.RegionalDexes) == 0 {
+		report.Issues = append(report.Issues, Issue{Problem: "sem Pokédex regionais: faltam os ficheiros api_data/pokedex_*.json"})
+	}
+	if !hasLearnsets() {
+		report.Issues = append(report.Issues, Issue{Problem: "sem learnsets: faltam os ficheiros learnsets/*.json"})
+	}
+
+	for _, id := range expectedIDs() {
+		pokemon := pokedex.GetByID(id)
+		if pokemon == nil {
+			report.Issues = append(report.Issues, Issue{ID: id, Problem: "sem dados"})
+			continue
+		}
+		report.Checked++
+
+		problem := func(format string, args ...any) {
+			report.Issues = append(report.Issues, Issue{ID: id, Name: pokemon.NameEN, Problem: fmt.Sprintf(format, args...)})
+		}
+
+		if !hasArt(pokemon, false, exists) {
+			problem("arte normal em falta")
+		}
+		if !hasArt(pokemon, true, exists) {
+			problem("arte shiny em falta")
+		}
+
+		stats := pokemon.Stats
+		for _, stat := range []struct {
+			name  string
+			value int
+		}{
+			{"hp", stats.HP}, {"attack", stats.Attack}, {"defense", stats.Defense},
+			{"special-attack", stats.SpAtk}, {"special-defense", stats.SpDef}, {"speed", stats.Speed},
+		} {
+			if stat.value <= 0 {
+				problem("estatística %s em falta", stat.name)
+			}
+		}
+
+		if n := len(pokemon.Types); n < 1 || n > 2 {
+			problem("%d tipos (esperados 1 ou 2)", n)
+		}
+		for _, t := range pokemon.Types {
+			if !knownTypes[t] {
+				problem("tipo desconhecido %q", t)
+			}
+		}
+
+		for _, form := range pokemon.Forms {
+			if n := len(form.Types); n < 1 || n > 2 {
+				problem("forma %s com %d tipos", form.Key, n)
+			}
+			if form.Stats.HP <= 0 {
+				problem("forma %s sem estatísticas", form.Key)
+			}
+		}
+
+		if pokemon.Generation < 1 || pokemon.Generation > 9 {
+			problem("sem geração")
+		}
+
+		if len(pokemon.Abilities) == 0 {
+			problem("sem habilidades")
+		}
+		for _, ability := range pokemon.Abilities {
+			if pokedex.GetAbility(ability.Key) == nil {
+				problem("habilidade %s fora do catálogo", ability.Key)
+			}
+		}
+		if pokemon.Species == nil {
+			if file := speciesFile(id); !exists(file) {
+				problem("%s em falta", file)
+			} else {
+				problem("sem dados de criação")
+			}
+		}
+
+		if len(pokemon.SignatureMoves) == 0 {
+			problem("sem movimentos característicos")
+		}
+		for _, move := range pokemon.SignatureMoves {
+			if !catalogMoves[strings.ToLower(move.NameEN)] {
+				problem("movimento %s fora do catálogo", move.NameEN)
+			}
+			if move.NameEN == "" || move.NamePT == "" {
+				problem("movimento sem nome")
+			}
+			if !knownTypes[move.Type] {
+				problem("movimento %s com tipo desconhecido %q", move.NameEN, move.Type)
+			}
+			if !moveCategories[move.Category] {
+				problem("movimento %s com categoria desconhecida %q", move.NameEN, move.Category)
+			}
+		}
+	}
+
+	return report
+}
+
+// hasLearnsets reports whether any expected Pokemon has a learnset. The
+// sample data leaves some out on purpose, so a single one will do.
+func hasLearnsets() bool {
+	for _, id := range expectedIDs() {
+		if _, err := LoadLearnset(id); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasArt reports whether a Pokemon has art: a sprite, baked half-block art
+// or, for the sample data, art stored on the Pokemon itself. exists looks up
+// asset paths.
+func hasArt(pokemon *models.Pokemon, shiny bool, exists func(path string) bool) bool {
+	suffix, legacy := "", pokemon.ArtStandard
+	if shiny {
+		suffix, legacy = "_shiny", pokemon.ArtShiny
+	}
+	return legacy != "" ||
+		exists(fmt.Sprintf("sprites/%d%s.png", pokemon.ID, suffix)) ||
+		exists(fmt.Sprintf("art/%d%s.ascii", pokemon.ID, suffix))
+}
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"charm-pokemon/models"
+)
+
+// TestValidate runs the validate command's checks on the data the build
+// embeds: every expected Pokemon must be present and complete.
+func TestValidate(t *testing.T) {
+	pokedex, err := LoadPokedex(nil)
+	report := Validate(pokedex, err)
+	if !report.OK() {
+		var out bytes.Buffer
+		report.Write(&out)
+		t.Fatalf("validation failed:\n%s", out.String())
+	}
+	if report.Checked != report.Expected {
+		t.Errorf("checked %d Pokemon, want %d", report.Checked, report.Expected)
+	}
+}
+
+func TestValidateReportsIncompleteData(t *testing.T) {
+	pokedex := models.NewPokedex()
+	pokedex.AddPokemon(&models.Pokemon{
+		ID:          1,
+		NameEN:      "Bulbasaur",
+		NamePT:      "Bulbasaur",
+		Types:       []string{"erva", "sombrio-ish"},
+		Stats:       models.PokemonStats{HP: 45, Attack: 49, Defense: 49, SpAtk: 65, SpDef: 65},
+		Generation:  1,
+		ArtStandard: "art",
+		Abilities:   []models.PokemonAbility{{Key: "overgrow"}},
+		SignatureMoves: []models.Move{
+			{NameEN: "Vine Whip", NamePT: "Chicote de Vinha", Type: "erva", Category: "physical"},
+		},
+	})
+	pokedex.AddMove(&models.MoveInfo{Key: "tackle", NameEN: "Tackle", Type: "normal", Category: "physical"})
+
+	// No assets, so the test doesn't depend on which ones the build embeds
+	report := validate(pokedex, nil, func(string) bool { return false })
+	if report.OK() {
+		t.Fatal("incomplete data passed validation")
+	}
+
+	var problems []string
+	for _, issue := range report.Issues {
+		if issue.ID == 1 || issue.ID == 0 {
+			problems = append(problems, issue.Move+issue.Problem)
+		}
+	}
+	got := strings.Join(problems, "\n")
+	for _, want := range []string{
+		"tacklenenhum Pokémon o aprende",
+		"catálogo de habilidades vazio",
+		"sem Pokédex regionais",
+		"arte shiny em falta",
+		"estatística speed em falta",
+		`tipo desconhecido "sombrio-ish"`,
+		"habilidade overgrow fora do catálogo",
+		"api_data/pokemon-species_1.json em falta",
+		"movimento Vine Whip fora do catálogo",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("issues do not report %q:\n%s", want, got)
+		}
+	}
+}
@@ -1,16 +1,22 @@
 package main
 
 import (
+	"charm-pokemon/assets"
 	"charm-pokemon/data"
 	"charm-pokemon/models"
 	"charm-pokemon/ui"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -65,11 +71,19 @@ type model struct {
 	shutdownPerc int              // percentage for shutdown animation
 	pokedex      *models.Pokedex
 	favorites    *models.FavoritesManager
+	team         *models.TeamManager
+	loading      bool          // the Pokedex is still loading
+	loadProgress data.Progress // how far loading got
+	loadErr      error         // problems met while loading
+	loadCh       chan tea.Msg  // progress updates from the loader
 	pokedexModel ui.PokedexModel
+	keys         ui.KeyMap
+	help         help.Model
+	width        int // terminal width
+	height       int // terminal height
 }
 
-func initialModel() model {
-	pokedex := data.GetPokedex()
+func initialModel(keys ui.KeyMap) model {
 	favorites := models.NewFavoritesManager()
 
 	return model{
@@ -79,16 +93,19 @@ func initialModel() model {
 		appsChoices:  []string{"Browser (MS Edge)", "Bloco de Notas", "Voltar ao Menu Principal"},
 		appsCursor:   0,
 		shutdownPerc: 100,
-		pokedex:      pokedex,
 		favorites:    favorites,
-		pokedexModel: ui.NewPokedexModel(pokedex, favorites),
+		loading:      true,
+		loadCh:       make(chan tea.Msg, 16),
+		pokedexModel: ui.NewPokedexModel(nil, favorites, nil, keys),
+		keys:         keys,
+		help:         help.New(),
 	}
 }
 
 func (m model) renderPikachu() string {
 	// Style for Pikachu - yellow color
 	pikachuStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("226")) // Bright Yellow
+		Foreground(ui.CurrentTheme().Pikachu)
 
 	artLines := strings.Split(pikachuArt, "\n")
 	maxWidth := 0
@@ -104,8 +121,8 @@ func (m model) renderPikachu() string {
 	welcomeMessage := "Olá Minês!"
 	messageStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")). // Nice blue/cyan color
-		Width(maxWidth).                  // Set an appropriate width for centering
+		Foreground(ui.CurrentTheme().Primary).
+		Width(maxWidth). // Set an appropriate width for centering
 		Align(lipgloss.Center)
 
 	// Combine the styled elements vertically - Pikachu first, then welcome message
@@ -116,13 +133,73 @@ func (m model) renderPikachu() string {
 }
 
 func (m model) Init() tea.Cmd {
+	return tea.Batch(
+		tea.SetWindowTitle("Pikachu Terminal"),
+		loadPokedex(m.loadCh),
+		waitForProgress(m.loadCh),
+	)
+}
+
+type loadProgressMsg data.Progress
+
+type loadDoneMsg struct {
+	pokedex *models.Pokedex
+	err     error
+}
+
+// loadPokedex loads the Pokedex in the background, sending progress to ch
+// and closing it when done.
+func loadPokedex(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		defer close(ch)
+		pokedex, err := data.LoadPokedex(func(p data.Progress) {
+			// Drop updates the UI has not caught up with; the next one
+			// carries the same information
+			select {
+			case ch <- loadProgressMsg(p):
+			default:
+			}
+		})
+		return loadDoneMsg{pokedex, err}
+	}
+}
 
-	return tea.SetWindowTitle("Pikachu Terminal")
+// waitForProgress delivers the next progress update, or nothing once the
+// loader is done.
+func waitForProgress(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
 
+// pokedexReady reports whether the Pokedex loaded with something to show.
+func (m model) pokedexReady() bool {
+	return m.pokedex != nil && len(m.pokedex.Pokemon) > 0
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		pokedexModel, cmd := m.pokedexModel.Update(msg)
+		m.pokedexModel = pokedexModel.(ui.PokedexModel)
+		return m, cmd
+
+	case tea.MouseMsg:
+		switch m.state {
+		case stateMainMenu, stateApps:
+			return m.updateMenuMouse(msg)
+		case statePokedex:
+			pokedexModel, cmd := m.pokedexModel.Update(msg)
+			m.pokedexModel = pokedexModel.(ui.PokedexModel)
+			return m, cmd
+		}
+
 	case tea.KeyMsg:
 		switch m.state {
 		case stateMainMenu:
@@ -132,7 +209,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case stateShutdown:
 			return m.updateShutdown(msg)
 		case statePokedex:
-			if msg.String() == "ctrl+c" {
+			if key.Matches(msg, m.keys.ForceQuit) {
 				return m, tea.Quit
 			}
 
@@ -146,9 +223,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	case loadProgressMsg:
+		if m.loading {
+			m.loadProgress = data.Progress(msg)
+		}
+		return m, waitForProgress(m.loadCh)
+
+	case loadDoneMsg:
+		m.loading = false
+		m.pokedex = msg.pokedex
+		m.loadErr = msg.err
+		if m.pokedexReady() {
+			m.team = models.NewTeamManager(m.pokedex)
+		}
+		return m, nil
+
 	case ui.MsgBack:
 		m.state = stateMainMenu
-		return m, nil
+		// Repaint from scratch so no inline image outlives the Pokedex
+		return m, tea.ClearScreen
 
 	case tickMsg:
 		if m.state == stateShutdown {
@@ -164,68 +257,125 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) updateMainMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch {
+	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
 
-	case "up", "k":
+	case key.Matches(msg, m.keys.Help):
+		m.help.ShowAll = !m.help.ShowAll
+
+	case key.Matches(msg, m.keys.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		}
 
-	case "down", "j":
+	case key.Matches(msg, m.keys.Down):
 		if m.cursor < len(m.choices)-1 {
 			m.cursor++
 		}
 
-	case "enter", " ":
-		switch m.cursor {
-		case 0: // Pokedex
-			m.state = statePokedex
-			m.pokedexModel = ui.NewPokedexModel(m.pokedex, m.favorites)
-		case 1: // Open Apps
-			m.state = stateApps
-			m.appsCursor = 0
-		case 2: // Power Off
-			m.state = stateShutdown
-			return m, tick()
+	case key.Matches(msg, m.keys.Select):
+		return m.selectMainMenu()
+	}
+	return m, nil
+}
+
+func (m model) selectMainMenu() (tea.Model, tea.Cmd) {
+	switch m.cursor {
+	case 0: // Pokedex
+		if !m.pokedexReady() {
+			return m, nil
 		}
+		m.state = statePokedex
+		m.pokedexModel = ui.NewPokedexModel(m.pokedex, m.favorites, m.team, m.keys)
+		pokedexModel, _ := m.pokedexModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		m.pokedexModel = pokedexModel.(ui.PokedexModel)
+	case 1: // Open Apps
+		m.state = stateApps
+		m.appsCursor = 0
+	case 2: // Power Off
+		m.state = stateShutdown
+		return m, tick()
 	}
 	return m, nil
 }
 
 func (m model) updateAppsMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch {
+	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
 
-	case "up", "k":
+	case key.Matches(msg, m.keys.Help):
+		m.help.ShowAll = !m.help.ShowAll
+
+	case key.Matches(msg, m.keys.Up):
 		if m.appsCursor > 0 {
 			m.appsCursor--
 		}
 
-	case "down", "j":
+	case key.Matches(msg, m.keys.Down):
 		if m.appsCursor < len(m.appsChoices)-1 {
 			m.appsCursor++
 		}
 
-	case "enter", " ":
-		switch m.appsCursor {
-		case 0: // Open Browser (Edge)
-			_ = openBrowser() // Ignore error - app launch is best effort
-			// Remain in the same state
-		case 1: // Open Notepad
-			_ = openNotepad() // Ignore error - app launch is best effort
-			// Remain in the same state
-		case 2: // Back to main menu
-			m.state = stateMainMenu
+	case key.Matches(msg, m.keys.Select):
+		return m.selectAppsMenu()
+	}
+	return m, nil
+}
+
+func (m model) selectAppsMenu() (tea.Model, tea.Cmd) {
+	switch m.appsCursor {
+	case 0: // Open Browser (Edge)
+		_ = openBrowser() // Ignore error - app launch is best effort
+		// Remain in the same state
+	case 1: // Open Notepad
+		_ = openNotepad() // Ignore error - app launch is best effort
+		// Remain in the same state
+	case 2: // Back to main menu
+		m.state = stateMainMenu
+	}
+	return m, nil
+}
+
+// updateMenuMouse handles the wheel and clicks on the main and apps menus.
+// Items are hit-tested against the rows they are rendered on.
+func (m model) updateMenuMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	cursor, count, header := &m.cursor, len(m.choices), mainMenuHeader
+	if m.state == stateApps {
+		cursor, count, header = &m.appsCursor, len(m.appsChoices), appsMenuHeader
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		if *cursor > 0 {
+			*cursor--
+		}
+	case tea.MouseButtonWheelDown:
+		if *cursor < count-1 {
+			*cursor++
+		}
+	case tea.MouseButtonLeft:
+		top := strings.Count(m.renderPikachu()+"\n"+header, "\n")
+		index := ui.ViewRow(m.View(), msg.Y, m.height) - top
+		if index < 0 || index >= count {
+			return m, nil
 		}
+		*cursor = index
+		if m.state == stateApps {
+			return m.selectAppsMenu()
+		}
+		return m.selectMainMenu()
 	}
 	return m, nil
 }
 
 func (m model) updateShutdown(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.String() == "ctrl+c" || msg.String() == "q" {
+	if key.Matches(msg, m.keys.Quit) {
 		return m, tea.Quit
 	}
 	return m, nil
@@ -278,49 +428,77 @@ func (m model) View() string {
 	}
 }
 
+const (
+	mainMenuHeader = "Bem vinda ao Terminal Pikachu!\n\n" +
+		"Usa as setas para navegar, Enter para selecionar\n\n"
+	appsMenuHeader = "Apps disponíveis\n\n"
+)
+
 func (m model) mainMenuView() string {
-	s := "Bem vinda ao Terminal Pikachu!\n\n"
-	s += "Usa as setas para navegar, Enter para selecionar\n\n"
+	s := mainMenuHeader
 
 	// Iterate over choices
 	for i, choice := range m.choices {
 		cursor := " "
+		style := lipgloss.NewStyle()
 		if m.cursor == i {
 			cursor = ">"
-			choice = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(choice)
+			style = style.Foreground(ui.CurrentTheme().Primary)
+		}
+		// The Pokedex entry stays disabled until there is something to show
+		if i == 0 && !m.pokedexReady() {
+			style = style.Faint(true)
+			if m.loading {
+				choice += " (a carregar...)"
+			} else {
+				choice += " (indisponível)"
+			}
 		}
 
-		s += fmt.Sprintf("%s %s\n", cursor, choice)
+		s += fmt.Sprintf("%s %s\n", cursor, style.Render(choice))
 	}
 
-	s += "\nPressiona q para sair\n"
+	if m.loading {
+		s += fmt.Sprintf("\nA carregar a Pokédex...\n[%s] %d%%\n", progressBar(m.loadProgress.Percent(), 30), m.loadProgress.Percent())
+	}
+	if m.loadErr != nil {
+		warning := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Accent).Bold(true)
+		s += "\n" + warning.Render("⚠ Problemas ao carregar a Pokédex:") + "\n" + m.loadErr.Error() + "\n"
+	}
+
+	s += "\n" + m.help.View(m.keys.MenuHelp()) + "\n"
 	return s
 }
 
 func (m model) appsMenuView() string {
-	s := "Apps disponíveis\n\n"
+	s := appsMenuHeader
 
 	// Iterate over app choices
 	for i, choice := range m.appsChoices {
 		cursor := " "
 		if m.appsCursor == i {
 			cursor = ">"
-			choice = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(choice)
+			choice = lipgloss.NewStyle().Foreground(ui.CurrentTheme().Primary).Render(choice)
 		}
 
 		s += fmt.Sprintf("%s %s\n", cursor, choice)
 	}
 
-	s += "\nPressiona q para sair\n"
+	s += "\n" + m.help.View(m.keys.MenuHelp()) + "\n"
 	return s
 }
 
 func (m model) shutdownView() string {
 	s := "A desligar...\n\n"
 
-	// Create progress bar
-	width := 50
-	filled := int(float64(m.shutdownPerc) / 100.0 * float64(width))
+	s += fmt.Sprintf("[%s] %d%%\n", progressBar(m.shutdownPerc, 50), m.shutdownPerc)
+	s += "\nPressiona Ctrl+C para fechar\n"
+	return s
+}
+
+// progressBar draws a bar width cells wide, filled to perc percent.
+func progressBar(perc, width int) string {
+	filled := int(float64(perc) / 100.0 * float64(width))
 
 	progress := ""
 	for i := 0; i < width; i++ {
@@ -330,14 +508,233 @@ func (m model) shutdownView() string {
 			progress += "░"
 		}
 	}
+	return progress
+}
 
-	s += fmt.Sprintf("[%s] %d%%\n", progress, m.shutdownPerc)
-	s += "\nPressiona Ctrl+C para fechar\n"
-	return s
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Uso: %s [opções] [comando]\n\n", os.Args[0])
+	fmt.Fprintln(flag.CommandLine.Output(), "Comandos:")
+	fmt.Fprintln(flag.CommandLine.Output(), "  validate   verifica se os dados incluídos estão completos")
+	fmt.Fprintln(flag.CommandLine.Output(), "  team import FICHEIRO")
+	fmt.Fprintln(flag.CommandLine.Output(), "             importa uma equipa no formato Showdown (- lê da entrada)")
+	fmt.Fprintln(flag.CommandLine.Output(), "  team export")
+	fmt.Fprintln(flag.CommandLine.Output(), "             escreve a equipa no formato Showdown")
+	fmt.Fprintln(flag.CommandLine.Output(), "  random [opções]")
+	fmt.Fprintln(flag.CommandLine.Output(), "             gera uma equipa aleatória (random -h para as restrições)")
+	fmt.Fprintln(flag.CommandLine.Output(), "\nOpções:")
+	flag.PrintDefaults()
+}
+
+// runValidate checks the Pokedex data and prints a report, returning the
+// exit status: 0 when everything is in place, 1 otherwise.
+func runValidate() int {
+	pokedex, err := data.LoadPokedex(nil)
+	report := data.Validate(pokedex, err)
+	report.Write(os.Stdout)
+	if !report.OK() {
+		return 1
+	}
+	return 0
+}
+
+// runTeam imports a Showdown team into the saved one, listing every line it
+// skipped, or prints the saved team. It returns the exit status.
+func runTeam(args []string) int {
+	pokedex, err := data.LoadPokedex(nil)
+	if pokedex == nil || len(pokedex.Pokemon) == 0 {
+		fmt.Fprintf(os.Stderr, "Erro ao carregar a Pokédex: %v\n", err)
+		return 1
+	}
+	team := models.NewTeamManager(pokedex)
+
+	switch {
+	case len(args) == 1 && args[0] == "export":
+		fmt.Print(team.Export())
+		return 0
+
+	case len(args) == 2 && args[0] == "import":
+		var text []byte
+		if args[1] == "-" {
+			text, err = io.ReadAll(os.Stdin)
+		} else {
+			text, err = os.ReadFile(args[1])
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Erro: %v\n", err)
+			return 1
+		}
+		count, err := team.Import(string(text))
+		fmt.Printf("%d Pokémon importados para %s\n", count, team.FilePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	usage()
+	return 2
+}
+
+// runRandom draws a random team under the constraints given as flags and
+// prints it, with the seed that draws it again. It returns the exit status.
+func runRandom(args []string) int {
+	fs := flag.NewFlagSet("random", flag.ContinueOnError)
+	seed := fs.Int64("seed", time.Now().UnixNano(), "semente, para repetir uma equipa")
+	gens := fs.String("gen", "", "gerações permitidas, separadas por vírgulas (ex.: 1,2)")
+	types := fs.String("types", "", "tipos permitidos, separados por vírgulas (ex.: fogo,água)")
+	uniqueTypes := fs.Bool("unique-types", false, "sem tipos repetidos na equipa")
+	minBST := fs.Int("min-bst", 0, "total de estatísticas base mínimo")
+	maxBST := fs.Int("max-bst", 0, "total de estatísticas base máximo")
+	fullyEvolved := fs.Bool("fully-evolved", false, "só Pokémon que não evoluem mais")
+	noLegendaries := fs.Bool("no-legendaries", false, "sem Pokémon lendários nem míticos")
+	favorites := fs.Bool("favorites", false, "só Pokémon favoritos")
+	showdown := fs.Bool("showdown", false, "escreve a equipa no formato Showdown")
+	save := fs.Bool("save", false, "guarda a equipa gerada como a equipa atual")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	pokedex, err := data.LoadPokedex(nil)
+	if pokedex == nil || len(pokedex.Pokemon) == 0 {
+		fmt.Fprintf(os.Stderr, "Erro ao carregar a Pokédex: %v\n", err)
+		return 1
+	}
+
+	// Constraints the data cannot evaluate at all are refused up front
+	if *fullyEvolved && !pokedex.SupportsFullyEvolved() {
+		fmt.Fprintln(os.Stderr, "-fully-evolved indisponível: os dados não dizem que espécies evoluem")
+		return 2
+	}
+	if *noLegendaries && !pokedex.SupportsNoLegendaries() {
+		fmt.Fprintln(os.Stderr, "-no-legendaries indisponível: os dados não têm espécies")
+		return 2
+	}
+
+	constraints := models.TeamConstraints{
+		UniqueTypes:   *uniqueTypes,
+		MinBST:        *minBST,
+		MaxBST:        *maxBST,
+		FullyEvolved:  *fullyEvolved,
+		NoLegendaries: *noLegendaries,
+	}
+	for _, gen := range splitList(*gens) {
+		n, err := strconv.Atoi(gen)
+		if err != nil || len(pokedex.GetPokemonByGeneration(n)) == 0 {
+			fmt.Fprintf(os.Stderr, "Geração desconhecida: %s\n", gen)
+			return 2
+		}
+		constraints.Generations = append(constraints.Generations, n)
+	}
+	for _, t := range splitList(*types) {
+		if len(pokedex.GetPokemonByType(t)) == 0 {
+			fmt.Fprintf(os.Stderr, "Tipo desconhecido: %s\n", t)
+			return 2
+		}
+		constraints.Types = append(constraints.Types, t)
+	}
+	if *favorites {
+		constraints.Favorites = models.NewFavoritesManager().Favorites
+	}
+
+	generator, err := models.NewTeamGenerator(pokedex, constraints, *seed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro: %v\n", err)
+		return 1
+	}
+	team, genErr := generator.Generate()
+	if len(team) == 0 {
+		fmt.Fprintf(os.Stderr, "Erro: %v\n", genErr)
+		return 1
+	}
+
+	if *showdown {
+		fmt.Print(pokedex.FormatShowdown(models.NewTeam(team)))
+	} else {
+		for _, pokemon := range team {
+			fmt.Printf("#%-4d %-14s %-20s BST %d\n", pokemon.ID, pokemon.NamePT, strings.Join(pokemon.Types, "/"), pokemon.Stats.Total())
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Semente: %d (%d candidatos)\n", *seed, len(generator.Candidates()))
+
+	if *save {
+		teamManager := models.NewTeamManager(pokedex)
+		if err := teamManager.Set(models.NewTeam(team)); err != nil {
+			fmt.Fprintf(os.Stderr, "Erro: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "Guardada em %s\n", teamManager.FilePath)
+	}
+
+	if genErr != nil {
+		fmt.Fprintf(os.Stderr, "Aviso: %v\n", genErr)
+		return 1
+	}
+	return 0
+}
+
+// splitList splits a comma-separated flag value, dropping blanks.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 func main() {
-	p := tea.NewProgram(initialModel())
+	keymapPath := flag.String("keymap", ui.DefaultKeyMapPath(), "ficheiro JSON com teclas personalizadas")
+	themeName := flag.String("theme", "auto", "tema: auto, dark, light, high-contrast, colorblind ou ficheiro JSON")
+	assetsDir := flag.String("assets-dir", "", "diretório com dados, arte ou sprites que substituem os incluídos")
+	renderName := flag.String("render", "auto", "modo de imagem: auto, kitty, iterm, sixel, halfblock, quarterblock ou braille")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *assetsDir != "" {
+		if err := assets.SetOverrideDir(*assetsDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Aviso: a ignorar -assets-dir: %v\n", err)
+		}
+	}
+
+	switch flag.Arg(0) {
+	case "":
+	case "validate":
+		os.Exit(runValidate())
+	case "team":
+		os.Exit(runTeam(flag.Args()[1:]))
+	case "random":
+		os.Exit(runRandom(flag.Args()[1:]))
+	default:
+		fmt.Fprintf(os.Stderr, "Comando desconhecido: %s\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	theme, err := ui.LoadTheme(*themeName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Aviso: a usar o tema por defeito: %v\n", err)
+	}
+	ui.SetTheme(theme)
+
+	keys, err := ui.LoadKeyMap(*keymapPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Aviso: a usar teclas por defeito: %v\n", err)
+	}
+
+	ui.SetGraphics(ui.DetectGraphics())
+	if *renderName != "auto" {
+		mode, err := ui.ParseRenderMode(*renderName)
+		if err == nil {
+			err = ui.ForceRenderMode(mode)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Aviso: a escolher o modo de imagem automaticamente: %v\n", err)
+		}
+	}
+
+	p := tea.NewProgram(initialModel(keys), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Oops: %v", err)
 		os.Exit(1)
@@ -0,0 +1,90 @@
+package models
+
+import "sort"
+
+// Ability is an entry of the ability catalog. Key is the PokeAPI name, e.g.
+// "lightning-rod".
+type Ability struct {
+	ID       int
+	Key      string
+	NamePT   string
+	NameEN   string
+	EffectPT string
+	EffectEN string
+}
+
+// PokemonAbility is one of the abilities a Pokemon can have.
+type PokemonAbility struct {
+	Key    string
+	Hidden bool
+}
+
+func (p *Pokedex) AddAbility(ability *Ability) {
+	p.Abilities[ability.Key] = ability
+}
+
+// GetAbility returns the catalog entry for key, or nil when the data has
+// no catalog.
+func (p *Pokedex) GetAbility(key string) *Ability {
+	return p.Abilities[key]
+}
+
+func (p *Pokedex) GetPokemonByAbility(key string) []*Pokemon {
+	return p.ByAbility[key]
+}
+
+// AbilityKeys lists the abilities some Pokemon has, sorted by name.
+func (p *Pokedex) AbilityKeys() []string {
+	keys := make([]string, 0, len(p.ByAbility))
+	for key := range p.ByAbility {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return p.AbilityName(keys[i]) < p.AbilityName(keys[j])
+	})
+	return keys
+}
+
+// AbilityName returns the Portuguese name of an ability, falling back to the
+// English one and then to its key.
+func (p *Pokedex) AbilityName(key string) string {
+	if ability := p.Abilities[key]; ability != nil {
+		if ability.NamePT != "" {
+			return ability.NamePT
+		}
+		if ability.NameEN != "" {
+			return ability.NameEN
+		}
+	}
+	return titleKey(key)
+}
+
+// AbilityEffect returns the short effect of an ability in Portuguese, or in
+// English when there is no translation. It is empty without a catalog.
+func (p *Pokedex) AbilityEffect(key string) string {
+	ability := p.Abilities[key]
+	if ability == nil {
+		return ""
+	}
+	if ability.EffectPT != "" {
+		return ability.EffectPT
+	}
+	return ability.EffectEN
+}
+
+// indexAbilities adds pokemon under each ability it or one of its forms has.
+func (p *Pokedex) indexAbilities(pokemon *Pokemon) {
+	seen := make(map[string]bool)
+	add := func(abilities []PokemonAbility) {
+		for _, a := range abilities {
+			if !seen[a.Key] {
+				seen[a.Key] = true
+				p.ByAbility[a.Key] = append(p.ByAbility[a.Key], pokemon)
+			}
+		}
+	}
+	add(pokemon.Abilities)
+	for _, form := range pokemon.Forms {
+		add(form.Abilities)
+	}
+}
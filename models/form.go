@@ -0,0 +1,61 @@
+package models
+
+// FormKind groups the alternate forms of a species.
+type FormKind int
+
+const (
+	FormOther FormKind = iota
+	FormRegional
+	FormMega
+	FormGigantamax
+	FormCosmetic // same types and stats as the default form
+)
+
+// Form is a variety of a species with its own PokeAPI entry, like Alolan
+// Raichu or Mega Charizard X. ID is that entry's number (10001 and up), which
+// also names its sprites and art.
+type Form struct {
+	ID     int
+	Key    string // PokeAPI name, e.g. "charizard-mega-x"
+	NamePT string
+	NameEN string
+	Kind   FormKind
+	Types  []string
+	Height float64
+	Weight float64
+	Stats  PokemonStats
+
+	EVYield   PokemonStats
+	Abilities []PokemonAbility
+}
+
+// ArtID returns the number the Pokemon's sprites and art are stored under.
+func (p *Pokemon) ArtID() int {
+	if p.Form != nil {
+		return p.Form.ID
+	}
+	return p.ID
+}
+
+// WithForm returns the Pokemon as it is in Forms[i], or p itself when i is
+// out of range. The copy keeps the species ID, so favorites and navigation
+// still refer to the species.
+func (p *Pokemon) WithForm(i int) *Pokemon {
+	if i < 0 || i >= len(p.Forms) {
+		return p
+	}
+	form := p.Forms[i]
+	variant := *p
+	variant.Form = form
+	variant.NamePT = form.NamePT
+	variant.NameEN = form.NameEN
+	variant.Types = form.Types
+	variant.Height = form.Height
+	variant.Weight = form.Weight
+	variant.Stats = form.Stats
+	variant.EVYield = form.EVYield
+	if len(form.Abilities) > 0 {
+		variant.Abilities = form.Abilities
+	}
+	return &variant
+}
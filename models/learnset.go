@@ -0,0 +1,104 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// LearnMethod is how a Pokemon learns a move.
+type LearnMethod int
+
+const (
+	LearnLevelUp LearnMethod = iota
+	LearnMachine             // TM, HM or TR
+	LearnEgg
+	LearnTutor
+)
+
+// LearnMethods lists the methods in the order they are shown.
+var LearnMethods = []LearnMethod{LearnLevelUp, LearnMachine, LearnEgg, LearnTutor}
+
+// ParseLearnMethod maps a PokeAPI move-learn-method name to a LearnMethod.
+func ParseLearnMethod(name string) (LearnMethod, bool) {
+	switch name {
+	case "level-up":
+		return LearnLevelUp, true
+	case "machine":
+		return LearnMachine, true
+	case "egg":
+		return LearnEgg, true
+	case "tutor":
+		return LearnTutor, true
+	}
+	return 0, false
+}
+
+// LearnedMove is a move a Pokemon learns in some version group. Move is the
+// PokeAPI name, e.g. "thunder-shock". Level is only set for level-up moves,
+// where 0 means the move is learned on evolving.
+type LearnedMove struct {
+	Move   string
+	Method LearnMethod
+	Level  int
+}
+
+// Learnset is every move a Pokemon learns, per version group.
+type Learnset struct {
+	VersionGroups []string // oldest first
+	Moves         map[string][]LearnedMove
+}
+
+// ByMethod returns the moves learned by method in a version group:
+// level-up moves by level, the others by name.
+func (l *Learnset) ByMethod(versionGroup string, method LearnMethod) []LearnedMove {
+	var moves []LearnedMove
+	for _, move := range l.Moves[versionGroup] {
+		if move.Method == method {
+			moves = append(moves, move)
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		if moves[i].Level != moves[j].Level {
+			return moves[i].Level < moves[j].Level
+		}
+		return moves[i].Move < moves[j].Move
+	})
+	return moves
+}
+
+// versionGroupNames labels the PokeAPI version groups that do not read well
+// once their hyphens are dropped.
+var versionGroupNames = map[string]string{
+	"red-blue":                            "Red/Blue",
+	"gold-silver":                         "Gold/Silver",
+	"ruby-sapphire":                       "Ruby/Sapphire",
+	"firered-leafgreen":                   "FireRed/LeafGreen",
+	"diamond-pearl":                       "Diamond/Pearl",
+	"heartgold-soulsilver":                "HeartGold/SoulSilver",
+	"black-white":                         "Black/White",
+	"black-2-white-2":                     "Black 2/White 2",
+	"x-y":                                 "X/Y",
+	"omega-ruby-alpha-sapphire":           "Omega Ruby/Alpha Sapphire",
+	"sun-moon":                            "Sun/Moon",
+	"ultra-sun-ultra-moon":                "Ultra Sun/Ultra Moon",
+	"lets-go-pikachu-lets-go-eevee":       "Let's Go Pikachu/Eevee",
+	"sword-shield":                        "Sword/Shield",
+	"brilliant-diamond-and-shining-pearl": "Brilliant Diamond/Shining Pearl",
+	"scarlet-violet":                      "Scarlet/Violet",
+	"xd":                                  "XD",
+	"red-green-japan":                     "Red/Green (JP)",
+	"blue-japan":                          "Blue (JP)",
+}
+
+// VersionGroupName returns a readable name for a PokeAPI version group.
+func VersionGroupName(key string) string {
+	if name, ok := versionGroupNames[key]; ok {
+		return name
+	}
+	return titleKey(key)
+}
+
+// titleKey turns a PokeAPI name such as "the-teal-mask" into "The Teal Mask".
+func titleKey(key string) string {
+	return strings.Title(strings.ReplaceAll(key, "-", " "))
+}
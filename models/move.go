@@ -0,0 +1,77 @@
+package models
+
+import "sort"
+
+// MoveInfo is an entry of the move catalog. Key is the PokeAPI name, e.g.
+// "thunderbolt".
+type MoveInfo struct {
+	Key      string
+	NamePT   string
+	NameEN   string
+	Type     string // Portuguese, like Pokemon.Types
+	Category string // physical, special or status
+	Power    int    // 0 for status moves
+	Accuracy int    // 0 when the move never misses, or the data does not say
+	PP       int    // 0 when the data does not say
+
+	LearnedBy []int // numbers of the Pokemon and forms that learn the move
+}
+
+func (p *Pokedex) AddMove(move *MoveInfo) {
+	p.Moves[move.Key] = move
+}
+
+// GetMove returns the catalog entry for key, or nil when the catalog does
+// not have the move.
+func (p *Pokedex) GetMove(key string) *MoveInfo {
+	return p.Moves[key]
+}
+
+// MoveKeys lists the moves of the catalog, sorted by name.
+func (p *Pokedex) MoveKeys() []string {
+	keys := make([]string, 0, len(p.Moves))
+	for key := range p.Moves {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return p.MoveName(keys[i]) < p.MoveName(keys[j])
+	})
+	return keys
+}
+
+// MoveName returns the Portuguese name of a move, falling back to the
+// English one and then to its key.
+func (p *Pokedex) MoveName(key string) string {
+	if move := p.Moves[key]; move != nil {
+		if move.NamePT != "" {
+			return move.NamePT
+		}
+		if move.NameEN != "" {
+			return move.NameEN
+		}
+	}
+	return titleKey(key)
+}
+
+// GetPokemonByMove lists the Pokemon that learn a move, in National Pokedex
+// order. A form that learns it brings in its species.
+func (p *Pokedex) GetPokemonByMove(key string) []*Pokemon {
+	move := p.Moves[key]
+	if move == nil {
+		return nil
+	}
+	seen := make(map[int]bool)
+	var pokemon []*Pokemon
+	for _, id := range move.LearnedBy {
+		species := p.PokemonByID[id]
+		if species == nil {
+			species = p.formSpecies[id]
+		}
+		if species != nil && !seen[species.ID] {
+			seen[species.ID] = true
+			pokemon = append(pokemon, species)
+		}
+	}
+	sort.Slice(pokemon, func(i, j int) bool { return pokemon[i].ID < pokemon[j].ID })
+	return pokemon
+}
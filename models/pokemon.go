@@ -15,10 +15,15 @@ type Pokemon struct {
 	Weight         float64
 	BaseExperience int
 	Stats          PokemonStats
+	EVYield        PokemonStats // effort values earned by defeating it
+	Abilities      []PokemonAbility
 	SignatureMoves []Move
 	ArtStandard    string
 	ArtShiny       string
 	Evolution      *EvolutionChain
+	Species        *Species // nil when the data has no species file
+	Forms          []*Form  // alternate forms, without the default one
+	Form           *Form    // set on the copies returned by WithForm
 	IsFavorite     bool
 }
 
@@ -51,6 +56,20 @@ type Pokedex struct {
 	PokemonByName map[string]*Pokemon
 	ByGeneration  map[int][]*Pokemon
 	ByType        map[string][]*Pokemon
+	Abilities     map[string]*Ability
+	ByAbility     map[string][]*Pokemon
+	ByEggGroup    map[string][]*Pokemon
+	Moves         map[string]*MoveInfo
+	RegionalDexes []*RegionalDex
+
+	formSpecies map[int]*Pokemon // species of each form, by form number
+	evolvesInto map[string]bool  // species some other species evolves from
+
+	// Base stats, to rank a Pokemon against all of them, its generation
+	// or its types
+	stats             StatDistribution
+	statsByGeneration map[int]*StatDistribution
+	statsByType       map[string]*StatDistribution
 }
 
 func NewPokedex() *Pokedex {
@@ -60,6 +79,15 @@ func NewPokedex() *Pokedex {
 		PokemonByName: make(map[string]*Pokemon),
 		ByGeneration:  make(map[int][]*Pokemon),
 		ByType:        make(map[string][]*Pokemon),
+		Abilities:     make(map[string]*Ability),
+		ByAbility:     make(map[string][]*Pokemon),
+		ByEggGroup:    make(map[string][]*Pokemon),
+		Moves:         make(map[string]*MoveInfo),
+		formSpecies:   make(map[int]*Pokemon),
+		evolvesInto:   make(map[string]bool),
+
+		statsByGeneration: make(map[int]*StatDistribution),
+		statsByType:       make(map[string]*StatDistribution),
 	}
 }
 
@@ -73,6 +101,12 @@ func (p *Pokedex) AddPokemon(pokemon *Pokemon) {
 	for _, t := range pokemon.Types {
 		p.ByType[t] = append(p.ByType[t], pokemon)
 	}
+	p.indexAbilities(pokemon)
+	p.indexEggGroups(pokemon)
+	p.indexStats(pokemon)
+	for _, form := range pokemon.Forms {
+		p.formSpecies[form.ID] = pokemon
+	}
 }
 
 func (p *Pokedex) GetByID(id int) *Pokemon {
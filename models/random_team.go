@@ -0,0 +1,192 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// TeamConstraints narrows the Pokemon a random team is drawn from. The zero
+// value allows every Pokemon.
+type TeamConstraints struct {
+	Generations   []int        // introduced in one of these; empty for any
+	Types         []string     // having at least one of these; empty for any
+	UniqueTypes   bool         // no type shared by two members
+	MinBST        int          // lowest base stat total; 0 for no limit
+	MaxBST        int          // highest base stat total; 0 for no limit
+	FullyEvolved  bool         // only Pokemon that do not evolve further
+	NoLegendaries bool         // leave out legendary and mythical Pokemon
+	Favorites     map[int]bool // when not nil, only the Pokemon set here
+}
+
+// ErrUnknownConstraint is returned when the data cannot tell whether a
+// Pokemon meets a constraint, like the legendary check without species data.
+var ErrUnknownConstraint = errors.New("os dados não permitem avaliar a restrição")
+
+// SupportsFullyEvolved reports whether the data of pokedex can tell which
+// Pokemon are fully evolved at all, so the constraint is worth offering.
+func (p *Pokedex) SupportsFullyEvolved() bool {
+	return p.HasEvolutionData()
+}
+
+// SupportsNoLegendaries reports whether the data of pokedex can tell
+// legendary Pokemon apart at all, so the constraint is worth offering.
+func (p *Pokedex) SupportsNoLegendaries() bool {
+	return p.HasSpeciesData()
+}
+
+// Allows reports whether pokemon of pokedex may be drawn, leaving aside the
+// rest of the team. It fails with ErrUnknownConstraint rather than guess
+// when the data lacks what a constraint needs.
+func (c TeamConstraints) Allows(pokedex *Pokedex, pokemon *Pokemon) (bool, error) {
+	if len(c.Generations) > 0 && !containsInt(c.Generations, pokemon.Generation) {
+		return false, nil
+	}
+	if len(c.Types) > 0 && !sharesType(pokemon.Types, c.Types) {
+		return false, nil
+	}
+	bst := pokemon.Stats.Total()
+	if (c.MinBST > 0 && bst < c.MinBST) || (c.MaxBST > 0 && bst > c.MaxBST) {
+		return false, nil
+	}
+	if c.Favorites != nil && !c.Favorites[pokemon.ID] {
+		return false, nil
+	}
+	if c.FullyEvolved {
+		evolves, ok := pokedex.EvolvesFurther(pokemon)
+		if !ok {
+			return false, fmt.Errorf("%w \"só evoluções finais\": %s sem dados de evolução", ErrUnknownConstraint, pokemon.NamePT)
+		}
+		if evolves {
+			return false, nil
+		}
+	}
+	if c.NoLegendaries {
+		if pokemon.Species == nil {
+			return false, fmt.Errorf("%w \"sem lendários\": %s sem dados de espécie", ErrUnknownConstraint, pokemon.NamePT)
+		}
+		if pokemon.Species.Legendary || pokemon.Species.Mythical {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// fits reports whether pokemon can join team, which may have nil slots.
+func (c TeamConstraints) fits(pokemon *Pokemon, team []*Pokemon) bool {
+	for _, member := range team {
+		if member == nil {
+			continue
+		}
+		if member.ID == pokemon.ID || (c.UniqueTypes && sharesType(member.Types, pokemon.Types)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrNoCandidates is returned when no Pokemon meets the constraints.
+var ErrNoCandidates = errors.New("nenhum Pokémon cumpre as restrições")
+
+// TeamGenerator draws random teams under constraints. Two generators with
+// the same Pokedex, constraints and seed draw the same teams.
+type TeamGenerator struct {
+	Constraints TeamConstraints
+
+	candidates []*Pokemon
+	rng        *rand.Rand
+}
+
+// NewTeamGenerator prepares a generator drawing from the Pokemon of pokedex
+// that meet constraints. It fails when the data cannot tell whether some
+// Pokemon meets them (see Allows).
+func NewTeamGenerator(pokedex *Pokedex, constraints TeamConstraints, seed int64) (*TeamGenerator, error) {
+	g := &TeamGenerator{
+		Constraints: constraints,
+		rng:         rand.New(rand.NewSource(seed)),
+	}
+	for _, pokemon := range pokedex.Pokemon {
+		allowed, err := constraints.Allows(pokedex, pokemon)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			g.candidates = append(g.candidates, pokemon)
+		}
+	}
+	return g, nil
+}
+
+// Candidates lists the Pokemon the generator draws from, in Pokedex order.
+func (g *TeamGenerator) Candidates() []*Pokemon {
+	return g.candidates
+}
+
+// generateAttempts is how many shuffles Generate tries before giving up on
+// a full team. With unique types a greedy pick can paint itself into a
+// corner that another order avoids.
+const generateAttempts = 50
+
+// Generate draws a team of MaxTeamSize Pokemon. When the constraints do not
+// leave enough Pokemon it returns the largest team it found and an error.
+func (g *TeamGenerator) Generate() ([]*Pokemon, error) {
+	if len(g.candidates) == 0 {
+		return nil, ErrNoCandidates
+	}
+	var best []*Pokemon
+	for attempt := 0; attempt < generateAttempts; attempt++ {
+		team := make([]*Pokemon, 0, MaxTeamSize)
+		for _, i := range g.rng.Perm(len(g.candidates)) {
+			if pokemon := g.candidates[i]; g.Constraints.fits(pokemon, team) {
+				team = append(team, pokemon)
+				if len(team) == MaxTeamSize {
+					return team, nil
+				}
+			}
+		}
+		if len(team) > len(best) {
+			best = team
+		}
+	}
+	return best, fmt.Errorf("só foi possível juntar %d Pokémon com estas restrições", len(best))
+}
+
+// Reroll draws a new Pokemon for slot of team, one that fits with the other
+// members and is not the one there now.
+func (g *TeamGenerator) Reroll(team []*Pokemon, slot int) (*Pokemon, error) {
+	if slot < 0 || slot >= len(team) {
+		return nil, fmt.Errorf("lugar %d fora da equipa", slot+1)
+	}
+	others := make([]*Pokemon, len(team))
+	copy(others, team)
+	current := others[slot]
+	others[slot] = nil
+
+	for _, i := range g.rng.Perm(len(g.candidates)) {
+		pokemon := g.candidates[i]
+		if pokemon != current && g.Constraints.fits(pokemon, others) {
+			return pokemon, nil
+		}
+	}
+	return nil, errors.New("nenhum outro Pokémon cabe neste lugar")
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sharesType(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,273 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// randomPokedex holds three-stage lines across two generations, with a
+// legendary of each, all with species data.
+func randomPokedex() *Pokedex {
+	p := NewPokedex()
+	add := func(id int, name string, generation int, types []string, total int, from string, legendary bool) {
+		per := total / 6
+		p.AddPokemon(&Pokemon{
+			ID:         id,
+			NameEN:     name,
+			NamePT:     name,
+			Generation: generation,
+			Types:      types,
+			Stats:      PokemonStats{HP: per, Attack: per, Defense: per, SpAtk: per, SpDef: per, Speed: total - 5*per},
+			Species:    &Species{Name: name, EvolvesFrom: from, Legendary: legendary},
+		})
+	}
+	add(1, "bulbasaur", 1, []string{"erva", "veneno"}, 318, "", false)
+	add(2, "ivysaur", 1, []string{"erva", "veneno"}, 405, "bulbasaur", false)
+	add(3, "venusaur", 1, []string{"erva", "veneno"}, 525, "ivysaur", false)
+	add(4, "charmander", 1, []string{"fogo"}, 309, "", false)
+	add(5, "charmeleon", 1, []string{"fogo"}, 405, "charmander", false)
+	add(6, "charizard", 1, []string{"fogo", "voador"}, 534, "charmeleon", false)
+	add(7, "squirtle", 1, []string{"água"}, 314, "", false)
+	add(8, "wartortle", 1, []string{"água"}, 405, "squirtle", false)
+	add(9, "blastoise", 1, []string{"água"}, 530, "wartortle", false)
+	add(25, "pikachu", 1, []string{"elétrico"}, 320, "pichu", false)
+	add(26, "raichu", 1, []string{"elétrico"}, 485, "pikachu", false)
+	add(143, "snorlax", 1, []string{"normal"}, 540, "munchlax", false)
+	add(150, "mewtwo", 1, []string{"psíquico"}, 680, "", true)
+	add(152, "chikorita", 2, []string{"erva"}, 318, "", false)
+	add(154, "meganium", 2, []string{"erva"}, 525, "bayleef", false)
+	add(155, "cyndaquil", 2, []string{"fogo"}, 309, "", false)
+	add(157, "typhlosion", 2, []string{"fogo"}, 534, "quilava", false)
+	add(158, "totodile", 2, []string{"água"}, 314, "", false)
+	add(160, "feraligatr", 2, []string{"água"}, 530, "croconaw", false)
+	add(172, "pichu", 2, []string{"elétrico"}, 205, "", false)
+	add(197, "umbreon", 2, []string{"sombrio"}, 525, "eevee", false)
+	add(249, "lugia", 2, []string{"psíquico", "voador"}, 680, "", true)
+	add(251, "celebi", 2, []string{"psíquico", "erva"}, 600, "", false)
+	p.GetByID(251).Species.Mythical = true
+	return p
+}
+
+func ids(team []*Pokemon) []int {
+	var ids []int
+	for _, pokemon := range team {
+		ids = append(ids, pokemon.ID)
+	}
+	return ids
+}
+
+func generate(t *testing.T, p *Pokedex, constraints TeamConstraints, seed int64) []*Pokemon {
+	t.Helper()
+	g, err := NewTeamGenerator(p, constraints, seed)
+	if err != nil {
+		t.Fatalf("NewTeamGenerator: %v", err)
+	}
+	team, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	return team
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	p := randomPokedex()
+	constraints := TeamConstraints{UniqueTypes: true}
+
+	differs := false
+	first := ids(generate(t, p, constraints, 1))
+	for seed := int64(1); seed <= 10; seed++ {
+		a := ids(generate(t, p, constraints, seed))
+		b := ids(generate(t, p, constraints, seed))
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("seed %d drew %v, then %v", seed, a, b)
+		}
+		differs = differs || !reflect.DeepEqual(a, first)
+	}
+	if !differs {
+		t.Errorf("seeds 1 to 10 all drew %v", first)
+	}
+}
+
+func TestGenerateMeetsConstraints(t *testing.T) {
+	p := randomPokedex()
+	favorites := map[int]bool{1: true, 4: true, 7: true, 25: true, 150: true, 249: true, 251: true}
+	tests := []struct {
+		name        string
+		constraints TeamConstraints
+		check       func(pokemon *Pokemon) bool
+	}{
+		{"generation", TeamConstraints{Generations: []int{2}}, func(pokemon *Pokemon) bool {
+			return pokemon.Generation == 2
+		}},
+		{"types", TeamConstraints{Types: []string{"fogo", "água"}}, func(pokemon *Pokemon) bool {
+			return sharesType(pokemon.Types, []string{"fogo", "água"})
+		}},
+		{"base stat total", TeamConstraints{MinBST: 400, MaxBST: 540}, func(pokemon *Pokemon) bool {
+			total := pokemon.Stats.Total()
+			return total >= 400 && total <= 540
+		}},
+		{"fully evolved", TeamConstraints{FullyEvolved: true}, func(pokemon *Pokemon) bool {
+			evolves, _ := p.EvolvesFurther(pokemon)
+			return !evolves
+		}},
+		{"no legendaries", TeamConstraints{NoLegendaries: true}, func(pokemon *Pokemon) bool {
+			return !pokemon.Species.Legendary && !pokemon.Species.Mythical
+		}},
+		{"favorites", TeamConstraints{Favorites: favorites}, func(pokemon *Pokemon) bool {
+			return favorites[pokemon.ID]
+		}},
+	}
+	for _, tt := range tests {
+		for seed := int64(1); seed <= 20; seed++ {
+			team := generate(t, p, tt.constraints, seed)
+			if len(team) != MaxTeamSize {
+				t.Errorf("%s, seed %d: %d Pokemon", tt.name, seed, len(team))
+			}
+			seen := make(map[int]bool)
+			for _, pokemon := range team {
+				if !tt.check(pokemon) {
+					t.Errorf("%s, seed %d: drew %s", tt.name, seed, pokemon.NameEN)
+				}
+				if seen[pokemon.ID] {
+					t.Errorf("%s, seed %d: drew %s twice", tt.name, seed, pokemon.NameEN)
+				}
+				seen[pokemon.ID] = true
+			}
+		}
+	}
+}
+
+func TestGenerateUniqueTypes(t *testing.T) {
+	p := randomPokedex()
+	for seed := int64(1); seed <= 20; seed++ {
+		team := generate(t, p, TeamConstraints{UniqueTypes: true}, seed)
+		types := make(map[string]string)
+		for _, pokemon := range team {
+			for _, typ := range pokemon.Types {
+				if other, ok := types[typ]; ok {
+					t.Errorf("seed %d: %s and %s are both %s", seed, other, pokemon.NameEN, typ)
+				}
+				types[typ] = pokemon.NameEN
+			}
+		}
+	}
+}
+
+func TestFullyEvolvedFromSpecies(t *testing.T) {
+	p := randomPokedex()
+	g, err := NewTeamGenerator(p, TeamConstraints{FullyEvolved: true, Generations: []int{1}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Pikachu and Snorlax evolve from species outside the data, which only
+	// matters for what they evolve into
+	if got, want := ids(g.Candidates()), []int{3, 6, 9, 26, 143, 150}; !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates = %v, want %v", got, want)
+	}
+}
+
+func TestGenerateTooFewCandidates(t *testing.T) {
+	p := randomPokedex()
+
+	g, err := NewTeamGenerator(p, TeamConstraints{Types: []string{"psíquico"}, UniqueTypes: true}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := g.Generate()
+	if err == nil || len(team) != 1 {
+		t.Errorf("Generate = %v, %v, want one Psychic Pokemon and an error", ids(team), err)
+	}
+
+	g, err = NewTeamGenerator(p, TeamConstraints{MinBST: 700}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Generate(); !errors.Is(err, ErrNoCandidates) {
+		t.Errorf("Generate error = %v, want ErrNoCandidates", err)
+	}
+}
+
+func TestReroll(t *testing.T) {
+	p := randomPokedex()
+	constraints := TeamConstraints{UniqueTypes: true}
+	g, err := NewTeamGenerator(p, constraints, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for slot := range team {
+		pokemon, err := g.Reroll(team, slot)
+		if err != nil {
+			t.Fatalf("Reroll(%d): %v", slot, err)
+		}
+		if pokemon == team[slot] {
+			t.Errorf("Reroll(%d) kept %s", slot, pokemon.NameEN)
+		}
+		others := append([]*Pokemon(nil), team...)
+		others[slot] = nil
+		if !constraints.fits(pokemon, others) {
+			t.Errorf("Reroll(%d) = %s, which does not fit the team", slot, pokemon.NameEN)
+		}
+	}
+
+	// The same seed rerolls the same way
+	var rerolled []int
+	for i := 0; i < 2; i++ {
+		g, _ := NewTeamGenerator(p, constraints, 7)
+		team, _ := g.Generate()
+		pokemon, err := g.Reroll(team, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rerolled = append(rerolled, pokemon.ID)
+	}
+	if rerolled[0] != rerolled[1] {
+		t.Errorf("seed 7 rerolled #%d, then #%d", rerolled[0], rerolled[1])
+	}
+
+	if _, err := g.Reroll(team, MaxTeamSize); err == nil {
+		t.Error("Reroll accepted a slot outside the team")
+	}
+}
+
+func TestUnknownConstraints(t *testing.T) {
+	p := randomPokedex()
+	p.AddPokemon(&Pokemon{ID: 999, NameEN: "unknown", NamePT: "Desconhecido", Generation: 3, Types: []string{"normal"}})
+
+	for _, constraints := range []TeamConstraints{{FullyEvolved: true}, {NoLegendaries: true}} {
+		if _, err := NewTeamGenerator(p, constraints, 1); !errors.Is(err, ErrUnknownConstraint) {
+			t.Errorf("%+v: error = %v, want ErrUnknownConstraint", constraints, err)
+		}
+		// Pokemon other constraints leave out are not evaluated
+		constraints.Generations = []int{1, 2}
+		if _, err := NewTeamGenerator(p, constraints, 1); err != nil {
+			t.Errorf("%+v: %v", constraints, err)
+		}
+	}
+
+	if !p.SupportsFullyEvolved() || !p.SupportsNoLegendaries() {
+		t.Error("the data supports neither constraint")
+	}
+
+	// Without evolution data no Pokemon can be told fully evolved
+	bare := NewPokedex()
+	bare.AddPokemon(&Pokemon{ID: 1, NameEN: "bulbasaur", Species: &Species{Name: "bulbasaur"}})
+	if _, err := NewTeamGenerator(bare, TeamConstraints{FullyEvolved: true}, 1); !errors.Is(err, ErrUnknownConstraint) {
+		t.Errorf("without evolution data: error = %v, want ErrUnknownConstraint", err)
+	}
+	if bare.SupportsFullyEvolved() || !bare.SupportsNoLegendaries() {
+		t.Error("without evolution data: SupportsFullyEvolved or not SupportsNoLegendaries")
+	}
+
+	// Without species data neither constraint is supported
+	bare = NewPokedex()
+	bare.AddPokemon(&Pokemon{ID: 1, NameEN: "bulbasaur"})
+	if bare.SupportsFullyEvolved() || bare.SupportsNoLegendaries() {
+		t.Error("without species data: a constraint is supported")
+	}
+}
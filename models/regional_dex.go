@@ -0,0 +1,79 @@
+package models
+
+// RegionalDex is the Pokedex of a game's region, with its own numbering,
+// like the Kanto dex of Red/Blue or the Paldea dex of Scarlet/Violet. Key is
+// the PokeAPI name, e.g. "original-johto".
+type RegionalDex struct {
+	ID      int
+	Key     string
+	NamePT  string
+	NameEN  string
+	Region  string // PokeAPI region name, empty for dexes of no single region
+	Entries []DexEntry
+}
+
+// DexEntry is a Pokemon's number in a regional dex. Numbers usually start at
+// 1, but some dexes open with a #0.
+type DexEntry struct {
+	Number    int
+	PokemonID int
+}
+
+// Name returns the Portuguese name of the dex, falling back to English and
+// then to its key.
+func (d *RegionalDex) Name() string {
+	if d.NamePT != "" {
+		return d.NamePT
+	}
+	if d.NameEN != "" {
+		return d.NameEN
+	}
+	return titleKey(d.Key)
+}
+
+// Number returns the number of a Pokemon in the dex, and whether it is in it
+// at all.
+func (d *RegionalDex) Number(pokemonID int) (int, bool) {
+	for _, entry := range d.Entries {
+		if entry.PokemonID == pokemonID {
+			return entry.Number, true
+		}
+	}
+	return 0, false
+}
+
+// AddRegionalDex adds a dex, keeping only the entries of Pokemon in the
+// Pokedex. Dexes are listed in the order they are added.
+func (p *Pokedex) AddRegionalDex(dex *RegionalDex) {
+	entries := make([]DexEntry, 0, len(dex.Entries))
+	for _, entry := range dex.Entries {
+		if p.PokemonByID[entry.PokemonID] != nil {
+			entries = append(entries, entry)
+		}
+	}
+	dex.Entries = entries
+	p.RegionalDexes = append(p.RegionalDexes, dex)
+}
+
+// GetRegionalDex returns the dex with the given key, or nil.
+func (p *Pokedex) GetRegionalDex(key string) *RegionalDex {
+	for _, dex := range p.RegionalDexes {
+		if dex.Key == key {
+			return dex
+		}
+	}
+	return nil
+}
+
+// GetPokemonByRegionalDex returns the Pokemon of a dex in its order.
+func (p *Pokedex) GetPokemonByRegionalDex(key string) []*Pokemon {
+	dex := p.GetRegionalDex(key)
+	if dex == nil {
+		return nil
+	}
+	pokemon := make([]*Pokemon, 0, len(dex.Entries))
+	for _, entry := range dex.Entries {
+		pokemon = append(pokemon, p.PokemonByID[entry.PokemonID])
+	}
+	return pokemon
+}
@@ -0,0 +1,443 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxTeamSize is how many Pokemon a team holds.
+const MaxTeamSize = 6
+
+// TeamMember is one Pokemon of a team, with what Pokemon Showdown's team
+// format says about it.
+type TeamMember struct {
+	Nickname string
+	Pokemon  *Pokemon // the species, or a copy for a form (see WithForm)
+	Gender   string   // "M", "F" or empty
+	Item     string
+	Ability  string // key when the Pokedex knows the ability, the name as written otherwise
+	Shiny    bool
+	TeraType string
+	Spread   StatSpread
+	Moves    []string // keys when the catalog knows the moves, names as written otherwise
+}
+
+// NewTeamMember is pokemon with the default spread and no moves.
+func NewTeamMember(pokemon *Pokemon) *TeamMember {
+	return &TeamMember{Pokemon: pokemon, Spread: DefaultSpread()}
+}
+
+// NewTeam makes a team member of each Pokemon.
+func NewTeam(pokemon []*Pokemon) []*TeamMember {
+	team := make([]*TeamMember, len(pokemon))
+	for i, p := range pokemon {
+		team[i] = NewTeamMember(p)
+	}
+	return team
+}
+
+// LineError is a line of a Showdown team that could not be used.
+type LineError struct {
+	Line int // 1-based
+	Text string
+	Err  error
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("linha %d: %v (%q)", e.Line, e.Err, e.Text)
+}
+
+// ShowdownError lists the lines ParseShowdown skipped. The team returned
+// alongside it holds everything else.
+type ShowdownError struct {
+	Lines []LineError
+}
+
+func (e *ShowdownError) Error() string {
+	if len(e.Lines) == 1 {
+		return e.Lines[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d linhas com erros", len(e.Lines))
+	for _, line := range e.Lines {
+		fmt.Fprintf(&b, "\n  %v", line)
+	}
+	return b.String()
+}
+
+// showdownStats are the stat names of the EVs and IVs lines.
+var showdownStats = map[Stat]string{
+	StatHP:      "HP",
+	StatAttack:  "Atk",
+	StatDefense: "Def",
+	StatSpAtk:   "SpA",
+	StatSpDef:   "SpD",
+	StatSpeed:   "Spe",
+}
+
+// showdownIgnored are lines of the format the Pokedex has no use for.
+var showdownIgnored = []string{"Happiness:", "Friendship:", "Hidden Power:", "Dynamax Level:", "Gigantamax:", "Pokeball:"}
+
+// ParseShowdown reads a team in Pokemon Showdown's export format: sets
+// separated by blank lines, each starting with "Nickname (Species) (M) @
+// Item". Species, abilities and moves are resolved against the Pokedex.
+// A set whose species is unknown is skipped whole; any other bad line is
+// skipped alone. Every skipped line is reported in a *ShowdownError.
+func (p *Pokedex) ParseShowdown(text string) ([]*TeamMember, error) {
+	var team []*TeamMember
+	problems := &ShowdownError{}
+	var member *TeamMember
+	skipping := false
+
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		fail := func(format string, args ...any) {
+			problems.Lines = append(problems.Lines, LineError{Line: i + 1, Text: line, Err: fmt.Errorf(format, args...)})
+		}
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "==="):
+			// A blank line or a "=== [gen9] Team ===" header ends the set
+			member, skipping = nil, false
+
+		case skipping:
+
+		case member == nil:
+			if len(team) == MaxTeamSize {
+				fail("a equipa já tem %d Pokémon", MaxTeamSize)
+				skipping = true
+				continue
+			}
+			var err error
+			member, err = p.parseShowdownHeader(line)
+			if err != nil {
+				fail("%v", err)
+				skipping = true
+				continue
+			}
+			team = append(team, member)
+
+		case strings.HasPrefix(line, "-") || strings.HasPrefix(line, "~"):
+			name := strings.TrimSpace(line[1:])
+			if len(member.Moves) == 4 {
+				fail("mais de 4 movimentos")
+				continue
+			}
+			move, err := p.resolveMove(name)
+			if err != nil {
+				fail("%v", err)
+				continue
+			}
+			member.Moves = append(member.Moves, move)
+
+		case strings.HasPrefix(line, "Ability:"):
+			ability, err := p.resolveAbility(member.Pokemon, strings.TrimSpace(strings.TrimPrefix(line, "Ability:")))
+			if err != nil {
+				fail("%v", err)
+				continue
+			}
+			member.Ability = ability
+
+		case strings.HasPrefix(line, "Level:"):
+			level, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "Level:")))
+			if err != nil || level < 1 || level > MaxLevel {
+				fail("nível fora de 1-%d", MaxLevel)
+				continue
+			}
+			member.Spread.Level = level
+
+		case strings.HasPrefix(line, "Shiny:"):
+			member.Shiny = strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(line, "Shiny:")), "yes")
+
+		case strings.HasPrefix(line, "Tera Type:"):
+			member.TeraType = strings.TrimSpace(strings.TrimPrefix(line, "Tera Type:"))
+
+		case strings.HasPrefix(line, "EVs:"):
+			evs := PokemonStats{}
+			if err := parseShowdownStats(strings.TrimPrefix(line, "EVs:"), &evs, MaxEV); err != nil {
+				fail("%v", err)
+				continue
+			}
+			if total := evs.Total(); total > MaxTotalEVs {
+				fail("%d EVs no total, máximo %d", total, MaxTotalEVs)
+				continue
+			}
+			member.Spread.EVs = evs
+
+		case strings.HasPrefix(line, "IVs:"):
+			ivs := DefaultSpread().IVs
+			if err := parseShowdownStats(strings.TrimPrefix(line, "IVs:"), &ivs, MaxIV); err != nil {
+				fail("%v", err)
+				continue
+			}
+			member.Spread.IVs = ivs
+
+		case strings.HasSuffix(line, " Nature"):
+			nature, ok := NatureByName(strings.TrimSuffix(line, " Nature"))
+			if !ok {
+				fail("natureza desconhecida")
+				continue
+			}
+			member.Spread.Nature = nature
+
+		case hasAnyPrefix(line, showdownIgnored):
+
+		default:
+			fail("linha não reconhecida")
+		}
+	}
+
+	if len(problems.Lines) > 0 {
+		return team, problems
+	}
+	return team, nil
+}
+
+// parseShowdownHeader reads "Nickname (Species) (M) @ Item", where all but
+// the species is optional.
+func (p *Pokedex) parseShowdownHeader(line string) (*TeamMember, error) {
+	member := &TeamMember{Spread: DefaultSpread()}
+	// Showdown's defaults, which are not the calculator's
+	member.Spread.Level = MaxLevel
+	member.Spread.Nature, _ = NatureByName("Serious")
+
+	if name, item, ok := strings.Cut(line, " @ "); ok {
+		line, member.Item = strings.TrimSpace(name), strings.TrimSpace(item)
+	}
+	for _, gender := range []string{"M", "F"} {
+		if strings.HasSuffix(line, " ("+gender+")") {
+			line, member.Gender = strings.TrimSuffix(line, " ("+gender+")"), gender
+		}
+	}
+	species := line
+	if open := strings.LastIndex(line, " ("); open > 0 && strings.HasSuffix(line, ")") {
+		member.Nickname = line[:open]
+		species = line[open+2 : len(line)-1]
+	}
+
+	member.Pokemon = p.FindPokemon(species)
+	if member.Pokemon == nil {
+		return nil, fmt.Errorf("Pokémon desconhecido: %s", species)
+	}
+	return member, nil
+}
+
+// parseShowdownStats reads "252 Atk / 4 SpD / 252 Spe" into stats.
+func parseShowdownStats(text string, stats *PokemonStats, max int) error {
+	for _, part := range strings.Split(text, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return fmt.Errorf("esperado \"número estatística\" em %q", strings.TrimSpace(part))
+		}
+		value, err := strconv.Atoi(fields[0])
+		if err != nil || value < 0 || value > max {
+			return fmt.Errorf("%s fora de 0-%d", fields[0], max)
+		}
+		stat, ok := showdownStat(fields[1])
+		if !ok {
+			return fmt.Errorf("estatística desconhecida: %s", fields[1])
+		}
+		stats.Set(stat, value)
+	}
+	return nil
+}
+
+func showdownStat(name string) (Stat, bool) {
+	for stat, short := range showdownStats {
+		if strings.EqualFold(short, name) {
+			return stat, true
+		}
+	}
+	return 0, false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAbility finds the ability key for name, and checks pokemon can have
+// it. Without an ability catalog the name is kept as written.
+func (p *Pokedex) resolveAbility(pokemon *Pokemon, name string) (string, error) {
+	if len(p.Abilities) == 0 {
+		return name, nil
+	}
+	ability := p.FindAbility(name)
+	if ability == nil {
+		return "", fmt.Errorf("habilidade desconhecida: %s", name)
+	}
+	if len(pokemon.Abilities) == 0 {
+		return ability.Key, nil
+	}
+	for _, a := range pokemon.Abilities {
+		if a.Key == ability.Key {
+			return ability.Key, nil
+		}
+	}
+	return "", fmt.Errorf("%s não pode ter a habilidade %s", pokemon.NamePT, name)
+}
+
+// resolveMove finds the move key for name. Without a move catalog the name
+// is kept as written. Whether the Pokemon learns the move is left alone:
+// that takes its learnset, which the Pokedex does not hold.
+func (p *Pokedex) resolveMove(name string) (string, error) {
+	if len(p.Moves) == 0 {
+		return name, nil
+	}
+	move := p.FindMove(name)
+	if move == nil {
+		return "", fmt.Errorf("movimento desconhecido: %s", name)
+	}
+	return move.Key, nil
+}
+
+// normalizeName reduces a name to its lowercase letters and digits, so
+// "Mr. Mime", "mr-mime" and "MrMime" all match.
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// FindPokemon looks a Pokemon up by its English, Portuguese, species or
+// Pokemon Showdown name, ignoring case, spaces and punctuation, so
+// "Giratina" finds Giratina-Altered. Forms are found by their names,
+// PokeAPI keys or Showdown names, e.g. "Charizard-Mega-X" or
+// "Darmanitan-Galar", and returned as a copy (see WithForm).
+func (p *Pokedex) FindPokemon(name string) *Pokemon {
+	if pokemon := p.PokemonByName[name]; pokemon != nil {
+		return pokemon
+	}
+	want := normalizeName(name)
+	if want == "" {
+		return nil
+	}
+	for _, pokemon := range p.Pokemon {
+		if normalizeName(pokemon.NameEN) == want || normalizeName(pokemon.NamePT) == want {
+			return pokemon
+		}
+	}
+	for _, pokemon := range p.Pokemon {
+		if pokemon.Species != nil && normalizeName(pokemon.Species.Name) == want {
+			return pokemon
+		}
+	}
+	for _, pokemon := range p.Pokemon {
+		for i, form := range pokemon.Forms {
+			if normalizeName(form.Key) == want || normalizeName(form.NameEN) == want || normalizeName(form.NamePT) == want {
+				return pokemon.WithForm(i)
+			}
+		}
+	}
+	if key, ok := showdownKeys[want]; ok {
+		return p.findByKey(key)
+	}
+	return nil
+}
+
+// FindAbility looks an ability up by its key or its English or Portuguese
+// name, ignoring case, spaces and punctuation.
+func (p *Pokedex) FindAbility(name string) *Ability {
+	want := normalizeName(name)
+	for _, ability := range p.Abilities {
+		if normalizeName(ability.Key) == want || normalizeName(ability.NameEN) == want || normalizeName(ability.NamePT) == want {
+			return ability
+		}
+	}
+	return nil
+}
+
+// FindMove looks a move up by its key or its English or Portuguese name,
+// ignoring case, spaces and punctuation.
+func (p *Pokedex) FindMove(name string) *MoveInfo {
+	want := normalizeName(name)
+	for _, move := range p.Moves {
+		if normalizeName(move.Key) == want || normalizeName(move.NameEN) == want || normalizeName(move.NamePT) == want {
+			return move
+		}
+	}
+	return nil
+}
+
+// FormatShowdown writes a team in Pokemon Showdown's export format, with the
+// English names Showdown expects (see ShowdownName).
+func (p *Pokedex) FormatShowdown(team []*TeamMember) string {
+	var b strings.Builder
+	for i, member := range team {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		species := ShowdownName(member.Pokemon)
+		header := species
+		if member.Nickname != "" && member.Nickname != species {
+			header = fmt.Sprintf("%s (%s)", member.Nickname, species)
+		}
+		if member.Gender != "" {
+			header += " (" + member.Gender + ")"
+		}
+		if member.Item != "" {
+			header += " @ " + member.Item
+		}
+		b.WriteString(header + "\n")
+
+		if member.Ability != "" {
+			name := member.Ability
+			if ability := p.Abilities[member.Ability]; ability != nil {
+				name = titleKey(ability.Key)
+				if ability.NameEN != "" {
+					name = ability.NameEN
+				}
+			}
+			fmt.Fprintf(&b, "Ability: %s\n", name)
+		}
+		if member.Spread.Level != MaxLevel {
+			fmt.Fprintf(&b, "Level: %d\n", member.Spread.Level)
+		}
+		if member.Shiny {
+			b.WriteString("Shiny: Yes\n")
+		}
+		if member.TeraType != "" {
+			fmt.Fprintf(&b, "Tera Type: %s\n", member.TeraType)
+		}
+		if evs := formatShowdownStats(member.Spread.EVs, 0); evs != "" {
+			fmt.Fprintf(&b, "EVs: %s\n", evs)
+		}
+		fmt.Fprintf(&b, "%s Nature\n", member.Spread.Nature.NameEN)
+		if ivs := formatShowdownStats(member.Spread.IVs, MaxIV); ivs != "" {
+			fmt.Fprintf(&b, "IVs: %s\n", ivs)
+		}
+		for _, key := range member.Moves {
+			name := key
+			if move := p.Moves[key]; move != nil {
+				name = titleKey(move.Key)
+				if move.NameEN != "" {
+					name = move.NameEN
+				}
+			}
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+	return b.String()
+}
+
+// formatShowdownStats lists the stats that differ from skip, e.g.
+// "252 Atk / 4 SpD / 252 Spe".
+func formatShowdownStats(stats PokemonStats, skip int) string {
+	var parts []string
+	for _, stat := range AllStats {
+		if value := stats.Get(stat); value != skip {
+			parts = append(parts, fmt.Sprintf("%d %s", value, showdownStats[stat]))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
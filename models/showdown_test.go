@@ -0,0 +1,222 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// showdownPokedex holds Pokemon named as in the real data, where NameEN is
+// the title-cased PokeAPI name, along with their forms.
+func showdownPokedex() *Pokedex {
+	p := NewPokedex()
+	add := func(id int, name string, species string, forms ...*Form) {
+		pokemon := &Pokemon{
+			ID:     id,
+			NameEN: name,
+			NamePT: name,
+			Types:  []string{"normal"},
+			Forms:  forms,
+		}
+		if species != "" {
+			pokemon.Species = &Species{Name: species}
+		}
+		p.AddPokemon(pokemon)
+	}
+	form := func(id int, key string) *Form {
+		return &Form{ID: id, Key: key, NameEN: key, NamePT: key, Types: []string{"normal"}}
+	}
+
+	add(29, "Nidoran-F", "nidoran-f")
+	add(122, "Mr-Mime", "mr-mime", form(10168, "mr-mime-galar"))
+	add(487, "Giratina-Altered", "giratina", form(10007, "giratina-origin"))
+	add(555, "Darmanitan-Standard", "", form(10017, "darmanitan-zen"), form(10177, "darmanitan-galar-standard"))
+	add(645, "Landorus-Incarnate", "", form(10021, "landorus-therian"))
+	add(678, "Meowstic-Male", "meowstic", form(10025, "meowstic-female"))
+	add(772, "Type-Null", "type-null")
+	add(778, "Mimikyu-Disguised", "mimikyu")
+	add(892, "Urshifu-Single-Strike", "urshifu", form(10191, "urshifu-rapid-strike"))
+
+	// Only Giratina lists its abilities; the others accept any
+	p.GetByID(487).Abilities = []PokemonAbility{{Key: "levitate"}}
+
+	p.AddAbility(&Ability{Key: "levitate", NameEN: "Levitate"})
+	p.AddAbility(&Ability{Key: "disguise", NameEN: "Disguise"})
+	p.AddAbility(&Ability{Key: "unseen-fist", NameEN: "Unseen Fist"})
+	p.AddMove(&MoveInfo{Key: "shadow-sneak", NameEN: "Shadow Sneak"})
+	p.AddMove(&MoveInfo{Key: "play-rough", NameEN: "Play Rough"})
+	p.AddMove(&MoveInfo{Key: "surging-strikes", NameEN: "Surging Strikes"})
+	p.AddMove(&MoveInfo{Key: "u-turn", NameEN: "U-turn"})
+	return p
+}
+
+func TestFindPokemonShowdownNames(t *testing.T) {
+	p := showdownPokedex()
+	tests := []struct {
+		name string
+		id   int
+		form string
+	}{
+		{"Giratina", 487, ""},
+		{"Giratina-Altered", 487, ""},
+		{"Giratina-Origin", 487, "giratina-origin"},
+		{"Mimikyu", 778, ""},
+		{"Mr. Mime", 122, ""},
+		{"Mr. Mime-Galar", 122, "mr-mime-galar"},
+		{"Nidoran-F", 29, ""},
+		{"Type: Null", 772, ""},
+		{"Darmanitan", 555, ""},
+		{"Darmanitan-Galar", 555, "darmanitan-galar-standard"},
+		{"Landorus", 645, ""},
+		{"Landorus-Therian", 645, "landorus-therian"},
+		{"Meowstic-F", 678, "meowstic-female"},
+		{"Urshifu", 892, ""},
+		{"Urshifu-Rapid-Strike", 892, "urshifu-rapid-strike"},
+	}
+	for _, tt := range tests {
+		pokemon := p.FindPokemon(tt.name)
+		if pokemon == nil {
+			t.Errorf("FindPokemon(%q) = nil, want #%d", tt.name, tt.id)
+			continue
+		}
+		form := ""
+		if pokemon.Form != nil {
+			form = pokemon.Form.Key
+		}
+		if pokemon.ID != tt.id || form != tt.form {
+			t.Errorf("FindPokemon(%q) = #%d %q, want #%d %q", tt.name, pokemon.ID, form, tt.id, tt.form)
+		}
+	}
+
+	for _, name := range []string{"", "Nidoran", "Urshifu-Wrong-Strike"} {
+		if pokemon := p.FindPokemon(name); pokemon != nil {
+			t.Errorf("FindPokemon(%q) = %s, want nil", name, pokemon.NameEN)
+		}
+	}
+}
+
+func TestShowdownName(t *testing.T) {
+	p := showdownPokedex()
+	tests := []struct {
+		pokemon *Pokemon
+		want    string
+	}{
+		{p.GetByID(487), "Giratina"},
+		{p.GetByID(487).WithForm(0), "Giratina-Origin"},
+		{p.GetByID(122), "Mr. Mime"},
+		{p.GetByID(122).WithForm(0), "Mr. Mime-Galar"},
+		{p.GetByID(29), "Nidoran-F"},
+		{p.GetByID(555).WithForm(1), "Darmanitan-Galar"},
+		{p.GetByID(645), "Landorus"},
+		{p.GetByID(645).WithForm(0), "Landorus-Therian"},
+		{p.GetByID(778), "Mimikyu"},
+		{p.GetByID(892).WithForm(0), "Urshifu-Rapid-Strike"},
+		{&Pokemon{NameEN: "Bulbasaur"}, "Bulbasaur"},
+	}
+	for _, tt := range tests {
+		if got := ShowdownName(tt.pokemon); got != tt.want {
+			t.Errorf("ShowdownName(%s) = %q, want %q", tt.pokemon.NameEN, got, tt.want)
+		}
+	}
+}
+
+// showdownTeam is a team as Pokemon Showdown exports it.
+const showdownTeam = `Giratina @ Leftovers
+Ability: Levitate
+Tera Type: Ghost
+EVs: 248 HP / 8 Def / 252 SpD
+Careful Nature
+- Shadow Sneak
+
+Ghost Girl (Mimikyu) (F) @ Life Orb
+Ability: Disguise
+Level: 50
+Shiny: Yes
+EVs: 252 Atk / 4 SpD / 252 Spe
+Jolly Nature
+IVs: 0 SpA
+- Shadow Sneak
+- Play Rough
+
+Urshifu-Rapid-Strike @ Choice Band
+Ability: Unseen Fist
+EVs: 252 Atk / 4 SpD / 252 Spe
+Adamant Nature
+- Surging Strikes
+- U-turn
+
+Mr. Mime
+Serious Nature
+
+Landorus-Therian
+Serious Nature
+
+Darmanitan-Galar
+Serious Nature
+`
+
+func TestShowdownRoundTrip(t *testing.T) {
+	p := showdownPokedex()
+	team, err := p.ParseShowdown(showdownTeam)
+	if err != nil {
+		t.Fatalf("ParseShowdown: %v", err)
+	}
+	if len(team) != 6 {
+		t.Fatalf("parsed %d Pokemon, want 6", len(team))
+	}
+
+	mimikyu := team[1]
+	if mimikyu.Nickname != "Ghost Girl" || mimikyu.Pokemon.ID != 778 || mimikyu.Gender != "F" || mimikyu.Item != "Life Orb" {
+		t.Errorf("header = %q #%d %q @ %q", mimikyu.Nickname, mimikyu.Pokemon.ID, mimikyu.Gender, mimikyu.Item)
+	}
+	if mimikyu.Ability != "disguise" || !mimikyu.Shiny || mimikyu.Spread.Level != 50 || mimikyu.Spread.Nature.NameEN != "Jolly" {
+		t.Errorf("set = %+v", mimikyu)
+	}
+	if mimikyu.Spread.EVs.Attack != 252 || mimikyu.Spread.IVs.SpAtk != 0 || mimikyu.Spread.IVs.HP != MaxIV {
+		t.Errorf("spread = %+v", mimikyu.Spread)
+	}
+	if want := []string{"shadow-sneak", "play-rough"}; !reflect.DeepEqual(mimikyu.Moves, want) {
+		t.Errorf("moves = %v, want %v", mimikyu.Moves, want)
+	}
+
+	if got := p.FormatShowdown(team); got != showdownTeam {
+		t.Errorf("FormatShowdown changed the team:\n%s\nwant:\n%s", got, showdownTeam)
+	}
+
+	again, err := p.ParseShowdown(p.FormatShowdown(team))
+	if err != nil {
+		t.Fatalf("ParseShowdown of the export: %v", err)
+	}
+	if !reflect.DeepEqual(again, team) {
+		t.Errorf("the exported team parses differently")
+	}
+}
+
+func TestParseShowdownErrors(t *testing.T) {
+	p := showdownPokedex()
+	team, err := p.ParseShowdown(`Missingno @ Leftovers
+Ability: Levitate
+- Shadow Sneak
+
+Giratina
+Ability: Disguise
+- Hyper Beam
+Brave Nature
+`)
+	if len(team) != 1 || team[0].Pokemon.ID != 487 {
+		t.Fatalf("team = %v, want Giratina alone", team)
+	}
+	var se *ShowdownError
+	if !errors.As(err, &se) {
+		t.Fatalf("error = %v, want a *ShowdownError", err)
+	}
+	var lines []int
+	for _, line := range se.Lines {
+		lines = append(lines, line.Line)
+	}
+	// The unknown species skips its set; the ability Giratina can't have and
+	// the unknown move are skipped alone
+	if want := []int{1, 6, 7}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines with errors = %v, want %v", lines, want)
+	}
+}
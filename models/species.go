@@ -0,0 +1,128 @@
+package models
+
+import "sort"
+
+// Genderless is the GenderRate of Pokemon without a gender.
+const Genderless = -1
+
+// Species is the breeding and training data PokeAPI keeps per species.
+// Name and keys are PokeAPI names, e.g. "giratina" for Giratina-Altered, or
+// "monster" and "medium-slow". GenderRate is the chance of being female in
+// eighths, and BaseHappiness is -1 when PokeAPI does not know it.
+type Species struct {
+	Name          string
+	EggGroups     []string
+	GenderRate    int
+	CaptureRate   int
+	BaseHappiness int
+	GrowthRate    string
+	HatchCounter  int
+	Habitat       string
+	Color         string
+	Legendary     bool
+	Mythical      bool
+	EvolvesFrom   string // species it evolves from, empty for the first stage
+}
+
+// HatchSteps is roughly how many steps an egg of the species takes to hatch.
+func (s *Species) HatchSteps() int {
+	return (s.HatchCounter + 1) * 255
+}
+
+// FemalePercent is the percentage of the species that is female, or -1 for
+// genderless species.
+func (s *Species) FemalePercent() float64 {
+	if s.GenderRate == Genderless {
+		return -1
+	}
+	return float64(s.GenderRate) * 100 / 8
+}
+
+// eggGroupNames are the Portuguese names of the egg groups.
+var eggGroupNames = map[string]string{
+	"monster":       "Monstro",
+	"water1":        "Água 1",
+	"water2":        "Água 2",
+	"water3":        "Água 3",
+	"bug":           "Inseto",
+	"flying":        "Voador",
+	"ground":        "Campo",
+	"fairy":         "Fada",
+	"plant":         "Planta",
+	"humanshape":    "Humanoide",
+	"mineral":       "Mineral",
+	"indeterminate": "Amorfo",
+	"ditto":         "Ditto",
+	"dragon":        "Dragão",
+	"no-eggs":       "Desconhecido",
+}
+
+// EggGroupName returns the Portuguese name of an egg group.
+func EggGroupName(key string) string {
+	if name, ok := eggGroupNames[key]; ok {
+		return name
+	}
+	return titleKey(key)
+}
+
+// GetPokemonByEggGroup returns the Pokemon in an egg group.
+func (p *Pokedex) GetPokemonByEggGroup(key string) []*Pokemon {
+	return p.ByEggGroup[key]
+}
+
+// EggGroupKeys returns every egg group some Pokemon is in, sorted by name.
+func (p *Pokedex) EggGroupKeys() []string {
+	keys := make([]string, 0, len(p.ByEggGroup))
+	for key := range p.ByEggGroup {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return EggGroupName(keys[i]) < EggGroupName(keys[j])
+	})
+	return keys
+}
+
+// indexEggGroups adds pokemon under each of its egg groups, and notes the
+// species it evolves from.
+func (p *Pokedex) indexEggGroups(pokemon *Pokemon) {
+	if pokemon.Species == nil {
+		return
+	}
+	for _, group := range pokemon.Species.EggGroups {
+		p.ByEggGroup[group] = append(p.ByEggGroup[group], pokemon)
+	}
+	if from := pokemon.Species.EvolvesFrom; from != "" {
+		p.evolvesInto[from] = true
+	}
+}
+
+// HasEvolutionData reports whether the species data says which species
+// evolve from which. Species files minified before that was kept leave every
+// species a first stage.
+func (p *Pokedex) HasEvolutionData() bool {
+	return len(p.evolvesInto) > 0
+}
+
+// HasSpeciesData reports whether any Pokemon has species data. Data built
+// before species were downloaded has none.
+func (p *Pokedex) HasSpeciesData() bool {
+	for _, pokemon := range p.Pokemon {
+		if pokemon.Species != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// EvolvesFurther reports whether pokemon has a later evolution, from its
+// evolution chain or else its species data. ok is false when the data has
+// neither.
+func (p *Pokedex) EvolvesFurther(pokemon *Pokemon) (evolves, ok bool) {
+	if pokemon.Evolution != nil {
+		return pokemon.Evolution.GetNextStage(pokemon.ID) != nil, true
+	}
+	if pokemon.Species == nil || pokemon.Species.Name == "" || !p.HasEvolutionData() {
+		return false, false
+	}
+	return p.evolvesInto[pokemon.Species.Name], true
+}
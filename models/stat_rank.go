@@ -0,0 +1,94 @@
+package models
+
+import "sort"
+
+// StatDistribution holds the values of every stat, and of the base stat
+// total, across a group of Pokemon, kept sorted so ranking one is a binary
+// search.
+type StatDistribution struct {
+	values [StatTotal + 1][]int // ascending
+}
+
+// StatRank places a value within a StatDistribution.
+type StatRank struct {
+	Rank       int     // 1 is the highest; ties share the best rank
+	Of         int     // how many Pokemon were compared
+	Percentile float64 // share of them below it, ties counting half, 0-100
+}
+
+func (d *StatDistribution) add(stats PokemonStats) {
+	for stat := range d.values {
+		value := stats.Get(Stat(stat))
+		values := d.values[stat]
+		i := sort.SearchInts(values, value)
+		values = append(values, 0)
+		copy(values[i+1:], values[i:])
+		values[i] = value
+		d.values[stat] = values
+	}
+}
+
+// Len returns how many Pokemon the distribution covers.
+func (d *StatDistribution) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.values[StatHP])
+}
+
+// Max returns the highest value of stat, or 0 when there is none.
+func (d *StatDistribution) Max(stat Stat) int {
+	if d == nil || stat < 0 || int(stat) >= len(d.values) || len(d.values[stat]) == 0 {
+		return 0
+	}
+	values := d.values[stat]
+	return values[len(values)-1]
+}
+
+// Rank places value among the values of stat.
+func (d *StatDistribution) Rank(stat Stat, value int) StatRank {
+	if d == nil || stat < 0 || int(stat) >= len(d.values) || len(d.values[stat]) == 0 {
+		return StatRank{}
+	}
+	values := d.values[stat]
+	lower := sort.SearchInts(values, value)
+	upper := sort.SearchInts(values, value+1)
+	return StatRank{
+		Rank:       len(values) - upper + 1,
+		Of:         len(values),
+		Percentile: (float64(lower) + float64(upper-lower)/2) * 100 / float64(len(values)),
+	}
+}
+
+func (p *Pokedex) indexStats(pokemon *Pokemon) {
+	p.stats.add(pokemon.Stats)
+
+	if p.statsByGeneration[pokemon.Generation] == nil {
+		p.statsByGeneration[pokemon.Generation] = &StatDistribution{}
+	}
+	p.statsByGeneration[pokemon.Generation].add(pokemon.Stats)
+
+	for _, t := range pokemon.Types {
+		if p.statsByType[t] == nil {
+			p.statsByType[t] = &StatDistribution{}
+		}
+		p.statsByType[t].add(pokemon.Stats)
+	}
+}
+
+// StatDistribution returns the stats of every Pokemon.
+func (p *Pokedex) StatDistribution() *StatDistribution {
+	return &p.stats
+}
+
+// GenerationStatDistribution returns the stats of the Pokemon introduced in
+// gen, or nil when there are none.
+func (p *Pokedex) GenerationStatDistribution(gen int) *StatDistribution {
+	return p.statsByGeneration[gen]
+}
+
+// TypeStatDistribution returns the stats of the Pokemon of a type, or nil
+// when there are none.
+func (p *Pokedex) TypeStatDistribution(typeName string) *StatDistribution {
+	return p.statsByType[typeName]
+}
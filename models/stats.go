@@ -0,0 +1,214 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stat names one of the six stats.
+type Stat int
+
+const (
+	StatHP Stat = iota
+	StatAttack
+	StatDefense
+	StatSpAtk
+	StatSpDef
+	StatSpeed
+
+	// StatTotal is the sum of the six, the base stat total for base stats.
+	// It is not in AllStats.
+	StatTotal
+)
+
+// AllStats lists the stats in the order the games show them.
+var AllStats = []Stat{StatHP, StatAttack, StatDefense, StatSpAtk, StatSpDef, StatSpeed}
+
+// Limits of the stat formulas.
+const (
+	MaxBaseStat = 255 // Blissey's HP, the highest base stat
+	MaxIV       = 31
+	MaxEV       = 252
+	MaxTotalEVs = 510
+	MaxLevel    = 100
+)
+
+// Get returns the value of one stat.
+func (s PokemonStats) Get(stat Stat) int {
+	switch stat {
+	case StatHP:
+		return s.HP
+	case StatAttack:
+		return s.Attack
+	case StatDefense:
+		return s.Defense
+	case StatSpAtk:
+		return s.SpAtk
+	case StatSpDef:
+		return s.SpDef
+	case StatSpeed:
+		return s.Speed
+	case StatTotal:
+		return s.Total()
+	}
+	return 0
+}
+
+// Set changes the value of one stat. StatTotal cannot be set.
+func (s *PokemonStats) Set(stat Stat, value int) {
+	switch stat {
+	case StatHP:
+		s.HP = value
+	case StatAttack:
+		s.Attack = value
+	case StatDefense:
+		s.Defense = value
+	case StatSpAtk:
+		s.SpAtk = value
+	case StatSpDef:
+		s.SpDef = value
+	case StatSpeed:
+		s.Speed = value
+	}
+}
+
+// Total returns the sum of the six stats, e.g. the base stat total.
+func (s PokemonStats) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpAtk + s.SpDef + s.Speed
+}
+
+// Nature raises one stat by 10% and lowers another by 10%. The five neutral
+// natures raise and lower the same stat, which cancels out.
+type Nature struct {
+	NameEN string
+	NamePT string
+	Up     Stat
+	Down   Stat
+}
+
+// Natures lists the 25 natures in their index order, five per raised stat.
+var Natures = []Nature{
+	{"Hardy", "Resistente", StatAttack, StatAttack},
+	{"Lonely", "Solitária", StatAttack, StatDefense},
+	{"Brave", "Corajosa", StatAttack, StatSpeed},
+	{"Adamant", "Firme", StatAttack, StatSpAtk},
+	{"Naughty", "Marota", StatAttack, StatSpDef},
+	{"Bold", "Ousada", StatDefense, StatAttack},
+	{"Docile", "Dócil", StatDefense, StatDefense},
+	{"Relaxed", "Descontraída", StatDefense, StatSpeed},
+	{"Impish", "Travessa", StatDefense, StatSpAtk},
+	{"Lax", "Descuidada", StatDefense, StatSpDef},
+	{"Timid", "Tímida", StatSpeed, StatAttack},
+	{"Hasty", "Apressada", StatSpeed, StatDefense},
+	{"Serious", "Séria", StatSpeed, StatSpeed},
+	{"Jolly", "Alegre", StatSpeed, StatSpAtk},
+	{"Naive", "Ingénua", StatSpeed, StatSpDef},
+	{"Modest", "Modesta", StatSpAtk, StatAttack},
+	{"Mild", "Suave", StatSpAtk, StatDefense},
+	{"Quiet", "Quieta", StatSpAtk, StatSpeed},
+	{"Bashful", "Envergonhada", StatSpAtk, StatSpAtk},
+	{"Rash", "Imprudente", StatSpAtk, StatSpDef},
+	{"Calm", "Calma", StatSpDef, StatAttack},
+	{"Gentle", "Gentil", StatSpDef, StatDefense},
+	{"Sassy", "Atrevida", StatSpDef, StatSpeed},
+	{"Careful", "Cuidadosa", StatSpDef, StatSpAtk},
+	{"Quirky", "Peculiar", StatSpDef, StatSpDef},
+}
+
+// NatureByName finds a nature by its English or Portuguese name, ignoring
+// case.
+func NatureByName(name string) (Nature, bool) {
+	for _, nature := range Natures {
+		if strings.EqualFold(nature.NameEN, name) || strings.EqualFold(nature.NamePT, name) {
+			return nature, true
+		}
+	}
+	return Nature{}, false
+}
+
+// Neutral reports whether the nature leaves every stat as it is.
+func (n Nature) Neutral() bool {
+	return n.Up == n.Down
+}
+
+// multiplier returns the nature's effect on stat in tenths.
+func (n Nature) multiplier(stat Stat) int {
+	switch {
+	case n.Neutral():
+		return 10
+	case stat == n.Up:
+		return 11
+	case stat == n.Down:
+		return 9
+	}
+	return 10
+}
+
+// StatSpread is everything besides the base stats that sets a Pokemon's
+// actual stats.
+type StatSpread struct {
+	Level  int
+	IVs    PokemonStats
+	EVs    PokemonStats
+	Nature Nature
+}
+
+// DefaultSpread is a level 50 Pokemon with perfect IVs, no EVs and a neutral
+// nature, the usual starting point for competitive play.
+func DefaultSpread() StatSpread {
+	perfect := PokemonStats{HP: MaxIV, Attack: MaxIV, Defense: MaxIV, SpAtk: MaxIV, SpDef: MaxIV, Speed: MaxIV}
+	return StatSpread{Level: 50, IVs: perfect, Nature: Natures[0]}
+}
+
+// Validate reports the first value of the spread the games do not allow.
+func (s StatSpread) Validate() error {
+	if s.Level < 1 || s.Level > MaxLevel {
+		return fmt.Errorf("nível %d fora de 1-%d", s.Level, MaxLevel)
+	}
+	for _, stat := range AllStats {
+		if iv := s.IVs.Get(stat); iv < 0 || iv > MaxIV {
+			return fmt.Errorf("IV %d fora de 0-%d", iv, MaxIV)
+		}
+		if ev := s.EVs.Get(stat); ev < 0 || ev > MaxEV {
+			return fmt.Errorf("EV %d fora de 0-%d", ev, MaxEV)
+		}
+	}
+	if total := s.EVs.Total(); total > MaxTotalEVs {
+		return fmt.Errorf("%d EVs no total, máximo %d", total, MaxTotalEVs)
+	}
+	return nil
+}
+
+// CalcStat applies the stat formula of the games since Generation III.
+// Shedinja, the only Pokemon with a base HP of 1, always has 1 HP.
+func CalcStat(stat Stat, base, iv, ev, level int, nature Nature) int {
+	core := (2*base + iv + ev/4) * level / 100
+	if stat == StatHP {
+		if base == 1 {
+			return 1
+		}
+		return core + level + 10
+	}
+	return (core + 5) * nature.multiplier(stat) / 10
+}
+
+// Calc returns the actual stats of a Pokemon with the given base stats.
+func (s StatSpread) Calc(base PokemonStats) PokemonStats {
+	var stats PokemonStats
+	for _, stat := range AllStats {
+		stats.Set(stat, CalcStat(stat, base.Get(stat), s.IVs.Get(stat), s.EVs.Get(stat), s.Level, s.Nature))
+	}
+	return stats
+}
+
+// StatRange returns the lowest and highest value a stat can reach at a
+// level: no IVs or EVs and a hindering nature, against perfect IVs, full
+// EVs and a helpful one.
+func StatRange(stat Stat, base, level int) (min, max int) {
+	hindering := Nature{Up: StatAttack, Down: stat}
+	helpful := Nature{Up: stat, Down: StatAttack}
+	if stat == StatAttack {
+		hindering.Up, helpful.Down = StatDefense, StatDefense
+	}
+	return CalcStat(stat, base, 0, 0, level, hindering), CalcStat(stat, base, MaxIV, MaxEV, level, helpful)
+}
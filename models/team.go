@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrTeamFull is returned when adding to a team of MaxTeamSize Pokemon.
+var ErrTeamFull = fmt.Errorf("a equipa já tem %d Pokémon", MaxTeamSize)
+
+// TeamManager keeps the user's team, saved in Showdown's format so the file
+// can be pasted straight into Showdown.
+type TeamManager struct {
+	Members  []*TeamMember
+	FilePath string
+
+	// Problems holds what the last load or import skipped, usually a
+	// *ShowdownError, or nil
+	Problems error
+
+	pokedex *Pokedex
+}
+
+// NewTeamManager loads the saved team, resolving it against pokedex. Lines
+// that no longer resolve are left in Problems.
+func NewTeamManager(pokedex *Pokedex) *TeamManager {
+	execDir, _ := os.Executable()
+	teamDir := filepath.Join(filepath.Dir(execDir), "assets")
+
+	if _, err := os.Stat(teamDir); os.IsNotExist(err) {
+		os.MkdirAll(teamDir, 0755)
+	}
+
+	tm := &TeamManager{
+		FilePath: filepath.Join(teamDir, "team.txt"),
+		pokedex:  pokedex,
+	}
+
+	tm.load()
+
+	return tm
+}
+
+func (tm *TeamManager) load() {
+	data, err := os.ReadFile(tm.FilePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			tm.Problems = err
+		}
+		return
+	}
+	tm.Members, tm.Problems = tm.pokedex.ParseShowdown(string(data))
+}
+
+func (tm *TeamManager) save() error {
+	return os.WriteFile(tm.FilePath, []byte(tm.Export()), 0644)
+}
+
+// Import replaces the team with the one in text, keeping the current team
+// when nothing in text could be read. It returns how many Pokemon were
+// imported; the lines that were skipped are returned and kept in Problems.
+func (tm *TeamManager) Import(text string) (int, error) {
+	members, problems := tm.pokedex.ParseShowdown(text)
+	if len(members) == 0 && problems == nil {
+		problems = errors.New("nenhum Pokémon encontrado")
+	}
+	tm.Problems = problems
+	if len(members) == 0 {
+		return 0, problems
+	}
+	tm.Members = members
+	if err := tm.save(); err != nil {
+		return len(members), err
+	}
+	return len(members), problems
+}
+
+// Export writes the team in Showdown's format.
+func (tm *TeamManager) Export() string {
+	return tm.pokedex.FormatShowdown(tm.Members)
+}
+
+// Set replaces the team with members.
+func (tm *TeamManager) Set(members []*TeamMember) error {
+	if len(members) > MaxTeamSize {
+		return ErrTeamFull
+	}
+	tm.Members = members
+	tm.Problems = nil
+	return tm.save()
+}
+
+// Add puts pokemon at the end of the team with the default spread.
+func (tm *TeamManager) Add(pokemon *Pokemon) error {
+	if len(tm.Members) >= MaxTeamSize {
+		return ErrTeamFull
+	}
+	tm.Members = append(tm.Members, NewTeamMember(pokemon))
+	tm.Problems = nil
+	return tm.save()
+}
+
+// Remove takes the member at index out of the team.
+func (tm *TeamManager) Remove(index int) error {
+	if index < 0 || index >= len(tm.Members) {
+		return nil
+	}
+	tm.Members = append(tm.Members[:index], tm.Members[index+1:]...)
+	tm.Problems = nil
+	return tm.save()
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"charm-pokemon/data"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Parses the minified JSON once and writes the Pokedex index the app loads
+// at startup.
+func main() {
+	inputDir := flag.String("in", "assets/embed", "directory with the minified api_data/")
+	outputPath := flag.String("out", "assets/embed/"+data.IndexFile, "index to write")
+	flag.Parse()
+
+	readFile := func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(*inputDir, filepath.FromSlash(name)))
+	}
+
+	// An index with holes would hide them from the app, so any problem is fatal
+	pokedex, err := data.LoadPokedexJSON(readFile, nil)
+	if err != nil {
+		fmt.Printf("Error loading %s: %v\n", *inputDir, err)
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err := data.WriteIndex(&buf, pokedex); err != nil {
+		fmt.Printf("Error encoding index: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*outputPath, buf.Bytes(), 0644); err != nil {
+		fmt.Printf("Error writing index: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Indexed %d Pokemon (%d KB, version %d)\n", len(pokedex.Pokemon), buf.Len()/1024, data.IndexVersion)
+}
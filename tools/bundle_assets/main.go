@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"charm-pokemon/assets"
+)
+
+// Packs the Pokedex index, the minified data, the learnsets, the ASCII art
+// and the PNG sprites into the single compressed bundle embedded by realdata builds.
+func main() {
+	inputDir := flag.String("in", "assets/embed", "directory with pokedex.gob, api_data/, learnsets/, art/ and sprites/")
+	outputPath := flag.String("out", "assets/embed/bundle.bin", "bundle to write")
+	flag.Parse()
+
+	patterns := []string{"pokedex.gob", "api_data/*.json", "learnsets/*.json", "art/*.ascii", "sprites/*.png"}
+
+	files := make(map[string][]byte)
+	var names []string
+	var rawSize int
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(*inputDir, pattern))
+		if err != nil {
+			panic(err)
+		}
+		for _, path := range matches {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				fmt.Printf("Error reading %s: %v\n", path, err)
+				os.Exit(1)
+			}
+			rel, _ := filepath.Rel(*inputDir, path)
+			name := filepath.ToSlash(rel)
+			files[name] = data
+			names = append(names, name)
+			rawSize += len(data)
+		}
+	}
+
+	if len(names) == 0 {
+		fmt.Printf("No assets found in %s\n", *inputDir)
+		os.Exit(1)
+	}
+	// Stable order, so rebuilding unchanged assets gives the same bundle
+	sort.Strings(names)
+
+	out, err := os.Create(*outputPath)
+	if err != nil {
+		panic(err)
+	}
+	if err := assets.WriteBundle(out, names, files); err != nil {
+		out.Close()
+		fmt.Printf("Error writing bundle: %v\n", err)
+		os.Exit(1)
+	}
+	if err := out.Close(); err != nil {
+		panic(err)
+	}
+
+	info, _ := os.Stat(*outputPath)
+	fmt.Printf("Bundled %d files: %.1f MB -> %.1f MB\n", len(names), float64(rawSize)/1e6, float64(info.Size())/1e6)
+}
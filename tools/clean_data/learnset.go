@@ -0,0 +1,111 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompactLearnset is every move a Pokemon learns, in every version group.
+// Moves, methods and version groups are stored once and referenced by index,
+// which keeps the thousands of entries of a Pokemon small.
+type CompactLearnset struct {
+	VersionGroups []string `json:"version_groups"` // oldest first
+	Methods       []string `json:"methods"`
+	Moves         []string `json:"moves"`
+	Entries       [][4]int `json:"entries"` // move, method, level, version group
+}
+
+// learnMethods are the methods the app groups moves by. The rest (form
+// changes, event-only moves) are left out.
+var learnMethods = []string{"level-up", "machine", "egg", "tutor"}
+
+// compactLearnset builds the learnset from the "moves" of a raw PokeAPI
+// pokemon entry.
+func compactLearnset(rawMoves []interface{}) CompactLearnset {
+	learnset := CompactLearnset{Methods: learnMethods}
+	methodIndex := make(map[string]int)
+	for i, m := range learnMethods {
+		methodIndex[m] = i
+	}
+
+	// Version groups are numbered in release order
+	groupIDs := make(map[string]int)
+	moveIndex := make(map[string]int)
+	type entry struct {
+		move, method, level int
+		group               string
+	}
+	var entries []entry
+
+	for _, m := range rawMoves {
+		mObj, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		moveInfo, _ := mObj["move"].(map[string]interface{})
+		name, _ := moveInfo["name"].(string)
+		details, _ := mObj["version_group_details"].([]interface{})
+		if name == "" {
+			continue
+		}
+
+		for _, d := range details {
+			detail, ok := d.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			methodInfo, _ := detail["move_learn_method"].(map[string]interface{})
+			method, known := methodIndex[stringField(methodInfo, "name")]
+			if !known {
+				continue
+			}
+			groupInfo, _ := detail["version_group"].(map[string]interface{})
+			group := stringField(groupInfo, "name")
+			if group == "" {
+				continue
+			}
+			if _, seen := groupIDs[group]; !seen {
+				groupIDs[group] = urlID(stringField(groupInfo, "url"))
+			}
+			if _, seen := moveIndex[name]; !seen {
+				moveIndex[name] = len(learnset.Moves)
+				learnset.Moves = append(learnset.Moves, name)
+			}
+			level, _ := detail["level_learned_at"].(float64)
+			entries = append(entries, entry{moveIndex[name], method, int(level), group})
+		}
+	}
+
+	for group := range groupIDs {
+		learnset.VersionGroups = append(learnset.VersionGroups, group)
+	}
+	sort.Slice(learnset.VersionGroups, func(i, j int) bool {
+		a, b := learnset.VersionGroups[i], learnset.VersionGroups[j]
+		if groupIDs[a] != groupIDs[b] {
+			return groupIDs[a] < groupIDs[b]
+		}
+		return a < b
+	})
+	groupIndex := make(map[string]int)
+	for i, group := range learnset.VersionGroups {
+		groupIndex[group] = i
+	}
+
+	for _, e := range entries {
+		learnset.Entries = append(learnset.Entries, [4]int{e.move, e.method, e.level, groupIndex[e.group]})
+	}
+	return learnset
+}
+
+func stringField(obj map[string]interface{}, key string) string {
+	s, _ := obj[key].(string)
+	return s
+}
+
+// urlID extracts the number that ends a PokeAPI resource URL.
+func urlID(url string) int {
+	id, _ := strconv.Atoi(path.Base(strings.TrimSuffix(url, "/")))
+	return id
+}
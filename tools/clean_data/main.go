@@ -2,9 +2,11 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -70,13 +72,33 @@ func formatMoveName(name string) string {
 }
 
 func main() {
-	inputDir := filepath.Join("..", "..", "assets", "api_data")
+	inputDir := flag.String("in", "assets/api_data", "directory with the downloaded pokemon_*.json files")
+	outputDir := flag.String("out", "assets/api_data_clean", "directory to write the cleaned files to")
+	flag.Parse()
+
+	// The raw files are what curate_moves reads, so they are never rewritten
+	if filepath.Clean(*inputDir) == filepath.Clean(*outputDir) {
+		fmt.Println("Error: -in and -out must be different directories")
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		fmt.Printf("Error creating output directory: %v\n", err)
+		os.Exit(1)
+	}
 
 	fmt.Println("Cleaning up Pokemon data (Filter & Trim)...")
 
-	for i := 1; i <= 1025; i++ {
+	// Forms have entries of their own, numbered from 10001
+	ids, err := pokemonIDs(*inputDir)
+	if err != nil {
+		fmt.Printf("Error listing %s: %v\n", *inputDir, err)
+		os.Exit(1)
+	}
+
+	learnedBy := make(learners)
+	for n, i := range ids {
 		fileName := fmt.Sprintf("pokemon_%d.json", i)
-		inputPath := filepath.Join(inputDir, fileName)
+		inputPath := filepath.Join(*inputDir, fileName)
 
 		data, err := os.ReadFile(inputPath)
 		if err != nil {
@@ -98,6 +120,8 @@ func main() {
 			"stats":  true,
 			"types":  true,
 			"moves":  true,
+
+			"abilities": true,
 		}
 
 		// Trim unused keys
@@ -107,11 +131,22 @@ func main() {
 			}
 		}
 
+		// The catalog's learners come from the full movesets only
+		if err := learnedBy.add(i, raw["moves"]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		rawMoves, ok := raw["moves"].([]interface{})
+
+		// The full learnset is kept apart from the signature moves below
+		if ok {
+			raw["learnset"] = compactLearnset(rawMoves)
+		}
+
 		// Filter and Inject Move Metadata
 		curatedNames := CuratedMoves[i]
 		newMoves := make([]map[string]interface{}, 0)
 
-		rawMoves, ok := raw["moves"].([]interface{})
 		if ok && len(curatedNames) > 0 {
 			// Create a map of existing moves for this pokemon to bridge to curated
 			existingMoves := make(map[string]bool)
@@ -180,15 +215,53 @@ func main() {
 
 		// Minified output
 		minData, _ := json.Marshal(raw)
-		err = os.WriteFile(inputPath, minData, 0644)
+		err = os.WriteFile(filepath.Join(*outputDir, fileName), minData, 0644)
 		if err != nil {
 			fmt.Printf("Error writing %s: %v\n", fileName, err)
 		}
 
-		if i%100 == 0 {
-			fmt.Printf("Processed %d/1025 Pokemon\n", i)
+		if (n+1)%100 == 0 {
+			fmt.Printf("Processed %d/%d Pokemon\n", n+1, len(ids))
 		}
 	}
 
+	// Generation, species, ability and pokedex files pass through untouched
+	// so the output is a complete api_data
+	for _, pattern := range []string{"generation_*.json", "pokemon-species_*.json", "ability_*.json", "pokedex_*.json"} {
+		matches, _ := filepath.Glob(filepath.Join(*inputDir, pattern))
+		for _, path := range matches {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			fileName := filepath.Base(path)
+			if err := os.WriteFile(filepath.Join(*outputDir, fileName), data, 0644); err != nil {
+				fmt.Printf("Error writing %s: %v\n", fileName, err)
+			}
+		}
+	}
+
+	if err := writeMoveCatalog(*inputDir, *outputDir, learnedBy); err != nil {
+		fmt.Printf("Error writing %s: %v\n", MoveCatalogFile, err)
+		os.Exit(1)
+	}
+
 	fmt.Println("Cleanup complete!")
 }
+
+// pokemonIDs lists the numbers of the pokemon_*.json files in dir, in order.
+func pokemonIDs(dir string) ([]int, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "pokemon_*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	for _, path := range matches {
+		var id int
+		if _, err := fmt.Sscanf(filepath.Base(path), "pokemon_%d.json", &id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
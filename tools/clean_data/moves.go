@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// MoveCatalogFile is the catalog of every move the app can show, written
+// next to the cleaned Pokemon.
+const MoveCatalogFile = "moves.json"
+
+// CatalogMove is a move of the catalog. Accuracy and PP are 0 when unknown,
+// which is the case for moves only MoveMetadataMap knows.
+type CatalogMove struct {
+	Name        string `json:"name"`
+	NameEN      string `json:"name_en,omitempty"`
+	NamePT      string `json:"name_pt,omitempty"`
+	Type        string `json:"type"`
+	DamageClass string `json:"damage_class"`
+	Power       int    `json:"power"`
+	Accuracy    int    `json:"accuracy"`
+	PP          int    `json:"pp"`
+	LearnedBy   []int  `json:"learned_by"`
+}
+
+// rawMove is the part of a PokeAPI move entry the catalog uses.
+type rawMove struct {
+	Name     string `json:"name"`
+	Accuracy *int   `json:"accuracy"`
+	PP       *int   `json:"pp"`
+	Power    *int   `json:"power"`
+	Type     struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	DamageClass struct {
+		Name string `json:"name"`
+	} `json:"damage_class"`
+	Names []struct {
+		Name     string `json:"name"`
+		Language struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"names"`
+}
+
+// learners collects which Pokemon learn each move.
+type learners map[string][]int
+
+// add records the moves of a raw Pokemon entry, its "moves" field. Only a
+// full PokeAPI entry lists every move the Pokemon learns with the games it
+// learns them in; an entry an earlier clean already trimmed, with no moves
+// or only its signature moves, would leave learners out, so it is an error.
+func (l learners) add(id int, moves interface{}) error {
+	rawMoves, ok := moves.([]interface{})
+	if !ok {
+		return fmt.Errorf("pokemon_%d.json has no moves: the data was already trimmed", id)
+	}
+	names := make([]string, 0, len(rawMoves))
+	for _, m := range rawMoves {
+		mObj, _ := m.(map[string]interface{})
+		if _, full := mObj["version_group_details"]; !full {
+			return fmt.Errorf("pokemon_%d.json lists only some moves: the data was already trimmed", id)
+		}
+		moveInfo, _ := mObj["move"].(map[string]interface{})
+		if name := stringField(moveInfo, "name"); name != "" {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		l[name] = append(l[name], id)
+	}
+	return nil
+}
+
+// writeMoveCatalog writes the catalog of the moves in MoveMetadataMap and
+// the downloaded move_*.json entries, which add accuracy, PP and translated
+// names.
+func writeMoveCatalog(inputDir, outputDir string, learnedBy learners) error {
+	catalog := make(map[string]*CatalogMove)
+	for name, meta := range MoveMetadataMap {
+		catalog[name] = &CatalogMove{
+			Name:        name,
+			Type:        meta.Type,
+			DamageClass: meta.DamageClass,
+			Power:       meta.Power,
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(inputDir, "move_*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var raw rawMove
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		move := &CatalogMove{
+			Name:        raw.Name,
+			Type:        raw.Type.Name,
+			DamageClass: raw.DamageClass.Name,
+		}
+		if raw.Power != nil {
+			move.Power = *raw.Power
+		}
+		if raw.Accuracy != nil {
+			move.Accuracy = *raw.Accuracy
+		}
+		if raw.PP != nil {
+			move.PP = *raw.PP
+		}
+		for _, n := range raw.Names {
+			switch n.Language.Name {
+			case "en":
+				move.NameEN = n.Name
+			case "pt", "pt-BR":
+				move.NamePT = n.Name
+			}
+		}
+		catalog[raw.Name] = move
+	}
+
+	names := make([]string, 0, len(catalog))
+	for name := range catalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	moves := make([]*CatalogMove, 0, len(names))
+	for _, name := range names {
+		move := catalog[name]
+		move.LearnedBy = learnedBy[name]
+		sort.Ints(move.LearnedBy)
+		moves = append(moves, move)
+	}
+
+	data, err := json.Marshal(moves)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outputDir, MoveCatalogFile), data, 0644)
+}
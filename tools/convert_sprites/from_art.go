@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// spritesFromArt rebuilds PNG sprites from half-block art. Each cell holds
+// two pixels, so the result is exact at the art resolution; use it when the
+// original sprites are not at hand.
+func spritesFromArt(artDir, pngDir string) error {
+	files, err := filepath.Glob(filepath.Join(artDir, "*.ascii"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no art found in %s", artDir)
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".ascii")
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Printf("Error reading %s: %v\n", name, err)
+			continue
+		}
+
+		img := halfBlocksToImage(string(data))
+		if err := savePNG(img, filepath.Join(pngDir, name+".png")); err != nil {
+			fmt.Printf("Error writing sprite %s: %v\n", name, err)
+			continue
+		}
+		fmt.Printf("Rebuilt %s\n", name)
+	}
+	return nil
+}
+
+// halfBlocksToImage is the inverse of imageToHalfBlocks.
+func halfBlocksToImage(art string) *image.NRGBA {
+	lines := strings.Split(strings.TrimRight(art, "\n"), "\n")
+
+	width := 0
+	for _, line := range lines {
+		if w := cellCount(line); w > width {
+			width = w
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, len(lines)*2))
+	for row, line := range lines {
+		var fg, bg *color.NRGBA
+		x := 0
+		for i := 0; i < len(line); {
+			if strings.HasPrefix(line[i:], "\x1b[") {
+				end := strings.IndexByte(line[i:], 'm')
+				if end < 0 {
+					break
+				}
+				applySGR(line[i+2:i+end], &fg, &bg)
+				i += end + 1
+				continue
+			}
+
+			r, size := utf8.DecodeRuneInString(line[i:])
+			i += size
+
+			switch {
+			case r == '▀' && fg != nil:
+				img.SetNRGBA(x, row*2, *fg)
+				if bg != nil {
+					img.SetNRGBA(x, row*2+1, *bg)
+				}
+			case r == '▄' && fg != nil:
+				img.SetNRGBA(x, row*2+1, *fg)
+				if bg != nil {
+					img.SetNRGBA(x, row*2, *bg)
+				}
+			}
+			x++
+		}
+	}
+	return img
+}
+
+func cellCount(line string) int {
+	n := 0
+	for i := 0; i < len(line); {
+		if strings.HasPrefix(line[i:], "\x1b[") {
+			end := strings.IndexByte(line[i:], 'm')
+			if end < 0 {
+				break
+			}
+			i += end + 1
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		n++
+	}
+	return n
+}
+
+func applySGR(params string, fg, bg **color.NRGBA) {
+	fields := strings.Split(params, ";")
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "", "0":
+			*fg, *bg = nil, nil
+		case "38", "48":
+			if i+4 < len(fields) && fields[i+1] == "2" {
+				c := &color.NRGBA{channel(fields[i+2]), channel(fields[i+3]), channel(fields[i+4]), 255}
+				if fields[i] == "48" {
+					*bg = c
+				} else {
+					*fg = c
+				}
+				i += 4
+			}
+		}
+	}
+}
+
+func channel(s string) uint8 {
+	n, _ := strconv.Atoi(s)
+	return uint8(n)
+}
@@ -2,33 +2,50 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"image"
-	_ "image/png"
+	"image/png"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/lucasb-eyer/go-colorful"
-	"github.com/mattn/go-sixel"
 	"golang.org/x/image/draw"
 )
 
 type ConversionConfig struct {
-	Width int
+	Width       int
+	SpriteWidth int // width of the embedded PNG sprites, in pixels
 }
 
 func main() {
+	inputDir := flag.String("in", "assets/sprites", "directory with the downloaded standard/ and shiny/ sprites")
+	outDir := flag.String("out", "assets/embed", "directory to write art/ and sprites/ into")
+	fromArt := flag.Bool("from-art", false, "rebuild the PNG sprites from the half-block art already in the output art/")
+	flag.Parse()
+
 	spriteDirs := []string{
-		"assets/sprites/standard",
-		"assets/sprites/shiny",
+		filepath.Join(*inputDir, "standard"),
+		filepath.Join(*inputDir, "shiny"),
 	}
-	outputDir := "assets/art"
+	outputDir := filepath.Join(*outDir, "art")
+	pngDir := filepath.Join(*outDir, "sprites")
 
 	os.MkdirAll(outputDir, 0755)
+	os.MkdirAll(pngDir, 0755)
 
 	config := ConversionConfig{
-		Width: 40,
+		Width:       40,
+		SpriteWidth: 96,
+	}
+
+	if *fromArt {
+		if err := spritesFromArt(outputDir, pngDir); err != nil {
+			fmt.Printf("Error rebuilding sprites: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
 	for _, spriteDir := range spriteDirs {
@@ -37,7 +54,7 @@ func main() {
 			panic(err)
 		}
 
-		isShiny := strings.Contains(spriteDir, "shiny")
+		isShiny := filepath.Base(spriteDir) == "shiny"
 		suffix := ""
 		if isShiny {
 			suffix = "_shiny"
@@ -56,10 +73,11 @@ func main() {
 			asciiPath := filepath.Join(outputDir, id+suffix+".ascii")
 			os.WriteFile(asciiPath, []byte(ascii), 0644)
 
-			// Generate Sixel
-			sixelArt := imageToSixel(img, config)
-			sixelPath := filepath.Join(outputDir, id+suffix+".sixel")
-			os.WriteFile(sixelPath, []byte(sixelArt), 0644)
+			// Generate the compact PNG the app draws every other mode from
+			pngPath := filepath.Join(pngDir, id+suffix+".png")
+			if err := writeSprite(img, config, pngPath); err != nil {
+				fmt.Printf("Error writing sprite %s%s: %v\n", id, suffix, err)
+			}
 
 			fmt.Printf("Converted %s%s\n", id, suffix)
 		}
@@ -126,23 +144,31 @@ func imageToHalfBlocks(img image.Image, config ConversionConfig) string {
 	return buf.String()
 }
 
-func imageToSixel(img image.Image, config ConversionConfig) string {
+// writeSprite scales img down to the sprite width and saves it as a PNG.
+func writeSprite(img image.Image, config ConversionConfig, path string) error {
 	bounds := img.Bounds()
-	ratio := float64(bounds.Dy()) / float64(bounds.Dx())
-	// For Sixel we can use a slightly higher resolution as it's pixel-based
-	targetWidth := config.Width * 8
-	targetHeight := int(float64(targetWidth) * ratio)
+	width := config.SpriteWidth
+	if bounds.Dx() < width {
+		width = bounds.Dx()
+	}
+	height := bounds.Dy() * width / bounds.Dx()
 
-	newRect := image.Rect(0, 0, targetWidth, targetHeight)
-	resized := image.NewRGBA(newRect)
-	draw.ApproxBiLinear.Scale(resized, newRect, img, bounds, draw.Over, nil)
+	newRect := image.Rect(0, 0, width, height)
+	resized := image.NewNRGBA(newRect)
+	draw.CatmullRom.Scale(resized, newRect, img, bounds, draw.Over, nil)
 
-	var buf bytes.Buffer
-	enc := sixel.NewEncoder(&buf)
-	err := enc.Encode(resized)
+	return savePNG(resized, path)
+}
+
+func savePNG(img image.Image, path string) error {
+	file, err := os.Create(path)
 	if err != nil {
-		return ""
+		return err
 	}
-
-	return buf.String()
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
 }
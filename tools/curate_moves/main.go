@@ -75,7 +75,7 @@ var jsonArrayRe = regexp.MustCompile(`(?s)\[[^\]]*\]`)
 
 func main() {
 	var (
-		rawDir       = flag.String("raw-dir", filepath.FromSlash("assets/api_data_raw_backup"), "Directory containing raw pokemon_*.json files")
+		rawDir       = flag.String("in", filepath.FromSlash("assets/api_data"), "Directory containing raw pokemon_*.json files")
 		metadataPath = flag.String("metadata", filepath.FromSlash("tools/clean_data/metadata.json"), "Path to move metadata JSON")
 		outGo        = flag.String("out", filepath.FromSlash("tools/clean_data/curated_moves.go"), "Output Go file to write")
 		cachePath    = flag.String("cache", filepath.FromSlash("tools/curate_moves/curated_moves_cache.json"), "Cache file (id -> curated moves)")
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// fileSum is what a downloaded file looked like when it was written.
+type fileSum struct {
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// checksums maps paths relative to the output directory to their sums, so a
+// rerun can tell a complete file from one that was cut short or edited.
+type checksums struct {
+	mu    sync.Mutex
+	files map[string]fileSum
+}
+
+func readChecksums(path string) (*checksums, error) {
+	c := &checksums{files: make(map[string]fileSum)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &c.files); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *checksums) write(path string) error {
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.files, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+func sumOf(data []byte) fileSum {
+	h := sha256.Sum256(data)
+	return fileSum{Size: int64(len(data)), SHA256: hex.EncodeToString(h[:])}
+}
+
+func (c *checksums) has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.files[key]
+	return ok
+}
+
+func (c *checksums) matches(key string, data []byte) bool {
+	c.mu.Lock()
+	want, ok := c.files[key]
+	c.mu.Unlock()
+	// Size first: it catches truncation without hashing
+	return ok && want.Size == int64(len(data)) && want == sumOf(data)
+}
+
+func (c *checksums) set(key string, data []byte) {
+	sum := sumOf(data)
+	c.mu.Lock()
+	c.files[key] = sum
+	c.mu.Unlock()
+}
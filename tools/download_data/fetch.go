@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	minBackoff = 500 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+type fileKind int
+
+const (
+	kindJSON fileKind = iota
+	kindPNG
+)
+
+type job struct {
+	src  source
+	name string // resource within src, like "pokemon/25" or "shiny/25.png"
+	path string
+	kind fileKind
+
+	optional bool // missing from the source is not a failure
+}
+
+type downloader struct {
+	root string // checksums are keyed relative to it
+	sums *checksums
+
+	fetched  atomic.Int64
+	skipped  atomic.Int64
+	failures atomic.Int64
+	missing  atomic.Int64
+	done     atomic.Int64
+}
+
+// run feeds jobs to a pool of workers and returns when they are all done or
+// ctx is cancelled.
+func (d *downloader) run(ctx context.Context, jobs []job, workers int) {
+	queue := make(chan job)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				err := d.fetch(ctx, j)
+				switch {
+				case err == nil || ctx.Err() != nil:
+				case j.optional && notFound(err):
+					d.missing.Add(1)
+				default:
+					fmt.Printf("Error downloading %s from %s: %v\n", j.name, j.src, err)
+					d.failures.Add(1)
+				}
+				if n := d.done.Add(1); n%progressPeriod == 0 {
+					fmt.Printf("Processed %d/%d files\n", n, len(jobs))
+				}
+			}
+		}()
+	}
+
+	for _, j := range jobs {
+		select {
+		case queue <- j:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// fetch downloads one file unless a valid copy is already on disk.
+func (d *downloader) fetch(ctx context.Context, j job) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	key := d.key(j.path)
+	if existing, err := os.ReadFile(j.path); err == nil {
+		if d.sums.matches(key, existing) {
+			d.skipped.Add(1)
+			return nil
+		}
+		// Files from before checksums were kept are trusted only if they parse
+		if !d.sums.has(key) && validate(j.kind, existing) == nil {
+			d.sums.set(key, existing)
+			d.skipped.Add(1)
+			return nil
+		}
+	}
+
+	body, err := j.src.read(ctx, j.name)
+	if err != nil {
+		return err
+	}
+	if err := validate(j.kind, body); err != nil {
+		return err
+	}
+	if j.kind == kindJSON {
+		// Indented like the rest of the raw data, for readable diffs
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, body, "", "  "); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+	}
+	if err := writeFileAtomic(j.path, body); err != nil {
+		return err
+	}
+	d.sums.set(key, body)
+	d.fetched.Add(1)
+	return nil
+}
+
+func (d *downloader) key(path string) string {
+	rel, err := filepath.Rel(d.root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// statusError is a response other than 200; 429 and 5xx are worth retrying.
+type statusError struct {
+	code       int
+	retryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.code)
+}
+
+func (e *statusError) temporary() bool {
+	return e.code == http.StatusTooManyRequests || e.code >= 500
+}
+
+// notFound reports whether err means the source doesn't have the file.
+func notFound(err error) bool {
+	var se *statusError
+	return errors.Is(err, fs.ErrNotExist) || errors.As(err, &se) && se.code == http.StatusNotFound
+}
+
+// httpSource fetches from a PokeAPI-compatible server, backing off
+// exponentially while it is busy or bodies come back short or malformed.
+type httpSource struct {
+	base    string
+	kind    fileKind
+	client  *http.Client
+	limiter *tokenBucket
+	retries int
+}
+
+func (s *httpSource) String() string {
+	return s.base
+}
+
+func (s *httpSource) read(ctx context.Context, name string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= s.retries; attempt++ {
+		if attempt > 0 {
+			wait := backoff(attempt)
+			var se *statusError
+			if errors.As(lastErr, &se) && se.retryAfter > wait {
+				wait = se.retryAfter
+			}
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		if err := s.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		body, err := s.getOnce(ctx, s.base+"/"+name)
+		if err == nil {
+			return body, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var se *statusError
+		if errors.As(err, &se) && !se.temporary() {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("giving up after %d attempts: %w", s.retries+1, lastErr)
+}
+
+func (s *httpSource) getOnce(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, &statusError{code: resp.StatusCode, retryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ContentLength >= 0 && int64(len(body)) != resp.ContentLength {
+		return nil, fmt.Errorf("got %d of %d bytes", len(body), resp.ContentLength)
+	}
+	if err := validate(s.kind, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// validate catches truncated and corrupt files, which sizes alone can't.
+func validate(kind fileKind, data []byte) error {
+	switch kind {
+	case kindJSON:
+		if !json.Valid(data) {
+			return errors.New("invalid JSON")
+		}
+	case kindPNG:
+		if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("invalid PNG: %w", err)
+		}
+	}
+	return nil
+}
+
+// backoff doubles from minBackoff with up to 50% jitter, so workers that
+// failed together don't retry together.
+func backoff(attempt int) time.Duration {
+	wait := minBackoff << (attempt - 1)
+	if wait > maxBackoff || wait <= 0 {
+		wait = maxBackoff
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter reads a Retry-After header in seconds; dates are ignored.
+func retryAfter(header string) time.Duration {
+	secs, err := strconv.Atoi(header)
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return min(time.Duration(secs)*time.Second, maxBackoff)
+}
+
+// writeFileAtomic writes through a temporary file so an interrupted download
+// never leaves a truncated file behind.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// tokenBucket spaces requests out to rate per second, allowing burst at once.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait blocks until a request may be made or ctx is cancelled.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return ctx.Err()
+	}
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
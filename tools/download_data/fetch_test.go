@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer answers each request with the next of responses, repeating the
+// last one, and counts the requests.
+func flakyServer(t *testing.T, responses ...func(w http.ResponseWriter)) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1)) - 1
+		responses[min(n, len(responses)-1)](w)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func status(code int) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) { w.WriteHeader(code) }
+}
+
+func body(text string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) { w.Write([]byte(text)) }
+}
+
+func testSource(base string, retries int) *httpSource {
+	return &httpSource{
+		base:    base,
+		kind:    kindJSON,
+		client:  http.DefaultClient,
+		limiter: newTokenBucket(0, 1),
+		retries: retries,
+	}
+}
+
+func TestHTTPSourceRetriesTemporaryErrors(t *testing.T) {
+	srv, requests := flakyServer(t, status(http.StatusServiceUnavailable), status(http.StatusTooManyRequests), body(`{"id": 25}`))
+
+	got, err := testSource(srv.URL, 3).read(context.Background(), "pokemon/25")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(got) != `{"id": 25}` {
+		t.Errorf("read = %q", got)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("%d requests, want 3", n)
+	}
+}
+
+func TestHTTPSourceRetriesMalformedBodies(t *testing.T) {
+	srv, requests := flakyServer(t, body(`{"id": 2`), body(`{"id": 25}`))
+
+	if _, err := testSource(srv.URL, 1).read(context.Background(), "pokemon/25"); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("%d requests, want 2", n)
+	}
+}
+
+func TestHTTPSourceGivesUp(t *testing.T) {
+	srv, requests := flakyServer(t, status(http.StatusBadGateway))
+
+	_, err := testSource(srv.URL, 1).read(context.Background(), "pokemon/25")
+	if err == nil || !strings.Contains(err.Error(), "giving up after 2 attempts") {
+		t.Errorf("read error = %v, want giving up after 2 attempts", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("%d requests, want 2", n)
+	}
+}
+
+func TestHTTPSourceDoesNotRetryNotFound(t *testing.T) {
+	srv, requests := flakyServer(t, status(http.StatusNotFound))
+
+	_, err := testSource(srv.URL, 3).read(context.Background(), "pokedex/40")
+	if !notFound(err) {
+		t.Errorf("read error = %v, want not found", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+}
+
+func TestHTTPSourceStopsOnCancel(t *testing.T) {
+	srv, _ := flakyServer(t, status(http.StatusServiceUnavailable))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := testSource(srv.URL, 10).read(ctx, "pokemon/25")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("read error = %v, want the context's", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("read took %v after the context ended", elapsed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, minBackoff / 2, minBackoff},
+		{2, minBackoff, 2 * minBackoff},
+		{3, 2 * minBackoff, 4 * minBackoff},
+		{20, maxBackoff / 2, maxBackoff},
+		{100, maxBackoff / 2, maxBackoff},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := backoff(tt.attempt); got < tt.min || got > tt.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"2", 2 * time.Second},
+		{"-1", 0},
+		{"3600", maxBackoff},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0},
+	}
+	for _, tt := range tests {
+		if got := retryAfter(tt.header); got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestTokenBucketLimitsRate(t *testing.T) {
+	srv, requests := flakyServer(t, body(`{}`))
+	source := testSource(srv.URL, 0)
+	source.limiter = newTokenBucket(50, 2)
+
+	// The burst goes at once; the other four wait 20ms each
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := source.read(context.Background(), "pokemon/1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("6 requests at 50/s with a burst of 2 took %v, want about 80ms", elapsed)
+	}
+	if n := requests.Load(); n != 6 {
+		t.Errorf("%d requests, want 6", n)
+	}
+}
+
+func TestTokenBucketStopsOnCancel(t *testing.T) {
+	bucket := newTokenBucket(0.001, 1)
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := bucket.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait = %v, want context.Canceled", err)
+	}
+}
+
+func TestFetchVerifiesChecksums(t *testing.T) {
+	const fresh = `{"id": 25}`
+	const indented = "{\n  \"id\": 25\n}"
+
+	tests := []struct {
+		name     string
+		existing string // "" for no file
+		summed   string // contents the checksum was taken of, "" for none
+		want     string
+		fetched  bool
+	}{
+		{"missing", "", "", indented, true},
+		{"matching checksum", `{"id": 1}`, `{"id": 1}`, `{"id": 1}`, false},
+		{"truncated", `{"id": 1`, `{"id": 1}`, indented, true},
+		{"edited", `{"id": 2}`, `{"id": 1}`, indented, true},
+		{"valid without checksum", `{"id": 1}`, "", `{"id": 1}`, false},
+		{"corrupt without checksum", `{"id": 1`, "", indented, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := flakyServer(t, body(fresh))
+			root := t.TempDir()
+			path := filepath.Join(root, "pokemon_25.json")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			d := &downloader{root: root, sums: &checksums{files: make(map[string]fileSum)}}
+			if tt.summed != "" {
+				d.sums.set("pokemon_25.json", []byte(tt.summed))
+			}
+
+			err := d.fetch(context.Background(), job{src: testSource(srv.URL, 0), name: "pokemon/25", path: path, kind: kindJSON})
+			if err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("file = %q, want %q", got, tt.want)
+			}
+			if fetched := requests.Load() > 0; fetched != tt.fetched {
+				t.Errorf("fetched = %v, want %v", fetched, tt.fetched)
+			}
+			if !d.sums.matches("pokemon_25.json", got) {
+				t.Errorf("checksum does not match the file")
+			}
+		})
+	}
+}
+
+func TestChecksumsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checksums.json")
+	sums := &checksums{files: make(map[string]fileSum)}
+	sums.set("pokemon_1.json", []byte(`{"id": 1}`))
+	if err := sums.write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := readChecksums(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !read.matches("pokemon_1.json", []byte(`{"id": 1}`)) {
+		t.Errorf("read checksums do not match the file")
+	}
+	if read.matches("pokemon_1.json", []byte(`{"id": 2}`)) {
+		t.Errorf("read checksums match a different file")
+	}
+}
@@ -1,200 +1,264 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"path"
 	"path/filepath"
-	"strconv"
-	"sync"
+	"strings"
+	"syscall"
+	"time"
 )
 
 const (
 	baseAPIURL     = "https://pokeapi.co/api/v2"
 	spriteBaseURL  = "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/other/official-artwork"
 	maxPokemonID   = 1025
-	outputDir      = "../../assets/api_data"
-	spriteDirStd   = "../../assets/sprites/standard"
-	spriteDirShiny = "../../assets/sprites/shiny"
+	maxGeneration  = 9
+	maxPokedexID   = 40
+	checksumsFile  = "checksums.json"
+	progressPeriod = 100
 )
 
 func main() {
-	fmt.Println("Downloading Pokemon data from PokeAPI...")
-
-	wg := sync.WaitGroup{}
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		downloadPokemonData()
-	}()
+	outDir := flag.String("out", "assets", "directory to download api_data/ and sprites/ into")
+	apiSpec := flag.String("source", baseAPIURL, "PokeAPI base URL, or a checkout or tarball of PokeAPI/api-data")
+	spriteSpec := flag.String("sprites", spriteBaseURL, "official artwork base URL, or a checkout or tarball of PokeAPI/sprites")
+	workers := flag.Int("concurrency", 8, "downloads running at once")
+	rate := flag.Float64("rate", 10, "requests per second across all workers (0 = unlimited)")
+	burst := flag.Int("burst", 5, "requests allowed at once before -rate applies")
+	retries := flag.Int("retries", 5, "retries for a file after 429, 5xx and network errors")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout for a single request")
+	flag.Parse()
+
+	if *workers < 1 {
+		fmt.Println("Error: -concurrency must be at least 1")
+		os.Exit(2)
+	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		downloadSprites(spriteDirStd, "")
-	}()
+	// Ctrl-C stops the workers; what finished is kept and a rerun resumes
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		downloadSprites(spriteDirShiny, "/shiny")
-	}()
+	sumsPath := filepath.Join(*outDir, checksumsFile)
+	sums, err := readChecksums(sumsPath)
+	if err != nil {
+		fmt.Printf("Error reading %s: %v\n", sumsPath, err)
+		os.Exit(1)
+	}
 
-	wg.Wait()
+	opts := httpOptions{
+		client:  &http.Client{Timeout: *timeout},
+		limiter: newTokenBucket(*rate, *burst),
+		retries: *retries,
+	}
+	api, cleanupAPI, err := newSource(*apiSpec, kindJSON, apiLayout, opts)
+	if err != nil {
+		fmt.Printf("Error opening %s: %v\n", *apiSpec, err)
+		os.Exit(1)
+	}
+	sprites, cleanupSprites, err := newSource(*spriteSpec, kindPNG, spriteLayout, opts)
+	if err != nil {
+		cleanupAPI()
+		fmt.Printf("Error opening %s: %v\n", *spriteSpec, err)
+		os.Exit(1)
+	}
 
-	fmt.Println("Download complete!")
+	fmt.Printf("Downloading from %s and %s (%d workers)...\n", api, sprites, *workers)
+	d := &downloader{root: *outDir, sums: sums}
+	code := d.download(ctx, *outDir, api, sprites, *workers, sumsPath)
+	cleanupAPI()
+	cleanupSprites()
+	os.Exit(code)
 }
 
-func downloadPokemonData() {
-	fmt.Println("Downloading Pokemon data...")
-
-	os.MkdirAll(outputDir, 0755)
-
-	for i := 1; i <= maxPokemonID; i++ {
-		if err := downloadSinglePokemon(i); err != nil {
-			fmt.Printf("Error downloading Pokemon %d: %v\n", i, err)
-		}
-
-		if i%50 == 0 {
-			fmt.Printf("Downloaded %d/%d Pokemon data\n", i, maxPokemonID)
+// download fetches the species, then the forms they list and then the
+// abilities and moves the Pokemon have, returning the exit status.
+func (d *downloader) download(ctx context.Context, outDir string, api, sprites source, workers int, sumsPath string) int {
+	d.run(ctx, downloadJobs(outDir, api, sprites), workers)
+	if ctx.Err() == nil {
+		forms, err := formJobs(outDir, api, sprites)
+		if err != nil {
+			fmt.Printf("Error listing forms: %v\n", err)
+			d.failures.Add(1)
 		}
+		d.run(ctx, forms, workers)
 	}
-
-	for i := 1; i <= 9; i++ {
-		if err := downloadGeneration(i); err != nil {
-			fmt.Printf("Error downloading generation %d: %v\n", i, err)
+	if ctx.Err() == nil {
+		referenced, err := referencedJobs(outDir, api)
+		if err != nil {
+			fmt.Printf("Error listing abilities and moves: %v\n", err)
+			d.failures.Add(1)
 		}
+		d.run(ctx, referenced, workers)
 	}
 
-	fmt.Println("Pokemon data download complete!")
-}
-
-func downloadSinglePokemon(id int) error {
-	url := fmt.Sprintf("%s/pokemon/%d", baseAPIURL, id)
-	filePath := filepath.Join(outputDir, fmt.Sprintf("pokemon_%d.json", id))
-
-	if _, err := os.Stat(filePath); err == nil {
-		return nil
+	if err := d.sums.write(sumsPath); err != nil {
+		fmt.Printf("Error writing %s: %v\n", sumsPath, err)
+		return 1
 	}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
+	fmt.Printf("%d downloaded, %d already present, %d optional files not available\n", d.fetched.Load(), d.skipped.Load(), d.missing.Load())
+	if ctx.Err() != nil {
+		fmt.Println("Download interrupted, run again to resume")
+		return 1
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	if n := d.failures.Load(); n > 0 {
+		fmt.Printf("Download incomplete: %d failures, run again to resume\n", n)
+		return 1
 	}
+	fmt.Println("Download complete!")
+	return 0
+}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+func apiNames() []string {
+	var names []string
+	for i := 1; i <= maxPokemonID; i++ {
+		names = append(names, fmt.Sprintf("pokemon/%d", i), fmt.Sprintf("pokemon-species/%d", i))
 	}
-
-	var prettyJSON map[string]interface{}
-	if err := json.Unmarshal(data, &prettyJSON); err != nil {
-		return err
+	for i := 1; i <= maxGeneration; i++ {
+		names = append(names, fmt.Sprintf("generation/%d", i))
 	}
+	return names
+}
 
-	formatted, err := json.MarshalIndent(prettyJSON, "", "  ")
-	if err != nil {
-		return err
+func spriteNames() []string {
+	var names []string
+	for _, dir := range []string{"", "shiny/"} {
+		for i := 1; i <= maxPokemonID; i++ {
+			names = append(names, fmt.Sprintf("%s%d.png", dir, i))
+		}
 	}
-
-	return os.WriteFile(filePath, formatted, 0644)
+	return names
 }
 
-func downloadGeneration(id int) error {
-	url := fmt.Sprintf("%s/generation/%d", baseAPIURL, id)
-	filePath := filepath.Join(outputDir, fmt.Sprintf("generation_%d.json", id))
-
-	if _, err := os.Stat(filePath); err == nil {
-		return nil
+// downloadJobs lists every file the app's data is built from.
+func downloadJobs(outDir string, api, sprites source) []job {
+	var jobs []job
+	for _, name := range apiNames() {
+		jobs = append(jobs, apiJob(outDir, api, name))
 	}
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
+	// PokeAPI numbers the regional dexes with gaps, so missing ones are fine
+	for i := 2; i <= maxPokedexID; i++ {
+		dex := apiJob(outDir, api, fmt.Sprintf("pokedex/%d", i))
+		dex.optional = true
+		jobs = append(jobs, dex)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	for _, name := range spriteNames() {
+		jobs = append(jobs, spriteJob(outDir, sprites, name))
 	}
+	return jobs
+}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+// apiJob saves name as a file of api_data, e.g. pokemon/25 as pokemon_25.json.
+func apiJob(outDir string, api source, name string) job {
+	return job{
+		src:  api,
+		name: name,
+		path: filepath.Join(outDir, "api_data", strings.Replace(name, "/", "_", 1)+".json"),
+		kind: kindJSON,
 	}
+}
 
-	var prettyJSON map[string]interface{}
-	if err := json.Unmarshal(data, &prettyJSON); err != nil {
-		return err
+func spriteJob(outDir string, sprites source, name string) job {
+	dir, file := path.Split(name)
+	if dir == "" {
+		dir = "standard"
 	}
-
-	formatted, err := json.MarshalIndent(prettyJSON, "", "  ")
-	if err != nil {
-		return err
+	return job{
+		src:  sprites,
+		name: name,
+		path: filepath.Join(outDir, "sprites", path.Clean(dir), file),
+		kind: kindPNG,
 	}
-
-	return os.WriteFile(filePath, formatted, 0644)
 }
 
-func downloadSprites(dir string, shinySuffix string) {
-	fmt.Printf("Downloading %s sprites...\n", dir)
-
-	os.MkdirAll(dir, 0755)
-
+// formJobs lists the entries and sprites of the alternate forms named in the
+// downloaded species. Not every form has official artwork, so its sprites
+// are optional.
+func formJobs(outDir string, api, sprites source) ([]job, error) {
+	var jobs []job
 	for i := 1; i <= maxPokemonID; i++ {
-		url := fmt.Sprintf("%s%s/%d.png", spriteBaseURL, shinySuffix, i)
-		filePath := filepath.Join(dir, fmt.Sprintf("%d.png", i))
-
-		if _, err := os.Stat(filePath); err == nil {
-			continue
-		}
-
-		resp, err := http.Get(url)
+		data, err := os.ReadFile(filepath.Join(outDir, "api_data", fmt.Sprintf("pokemon-species_%d.json", i)))
 		if err != nil {
-			fmt.Printf("Error downloading sprite %d: %v\n", i, err)
+			// Reported when the species itself failed to download
 			continue
 		}
-
-		if resp.StatusCode != http.StatusOK {
-			resp.Body.Close()
-			fmt.Printf("HTTP %d for sprite %d\n", resp.StatusCode, i)
-			continue
+		var species struct {
+			Varieties []struct {
+				IsDefault bool `json:"is_default"`
+				Pokemon   struct {
+					URL string `json:"url"`
+				} `json:"pokemon"`
+			} `json:"varieties"`
 		}
-
-		file, err := os.Create(filePath)
-		if err != nil {
-			resp.Body.Close()
-			fmt.Printf("Error creating file %d: %v\n", i, err)
-			continue
+		if err := json.Unmarshal(data, &species); err != nil {
+			return jobs, fmt.Errorf("species %d: %w", i, err)
 		}
+		for _, variety := range species.Varieties {
+			if variety.IsDefault {
+				continue
+			}
+			id := path.Base(strings.TrimSuffix(variety.Pokemon.URL, "/"))
+			jobs = append(jobs, apiJob(outDir, api, "pokemon/"+id))
+			for _, dir := range []string{"", "shiny/"} {
+				sprite := spriteJob(outDir, sprites, dir+id+".png")
+				sprite.optional = true
+				jobs = append(jobs, sprite)
+			}
+		}
+	}
+	return jobs, nil
+}
 
-		_, err = io.Copy(file, resp.Body)
-		file.Close()
-		resp.Body.Close()
-
+// referencedJobs lists the abilities and moves of the downloaded Pokemon and
+// forms, each once.
+func referencedJobs(outDir string, api source) ([]job, error) {
+	files, err := filepath.Glob(filepath.Join(outDir, "api_data", "pokemon_*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var jobs []job
+	seen := make(map[string]bool)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
 		if err != nil {
-			fmt.Printf("Error saving sprite %d: %v\n", i, err)
-			os.Remove(filePath)
-			continue
+			return jobs, err
 		}
-
-		if i%100 == 0 {
-			fmt.Printf("Downloaded %d/%d sprites\n", i, maxPokemonID)
+		var pokemon struct {
+			Abilities []struct {
+				Ability struct {
+					URL string `json:"url"`
+				} `json:"ability"`
+			} `json:"abilities"`
+			Moves []struct {
+				Move struct {
+					URL string `json:"url"`
+				} `json:"move"`
+			} `json:"moves"`
+		}
+		if err := json.Unmarshal(data, &pokemon); err != nil {
+			return jobs, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+		var names []string
+		for _, a := range pokemon.Abilities {
+			names = append(names, "ability/"+path.Base(strings.TrimSuffix(a.Ability.URL, "/")))
+		}
+		for _, m := range pokemon.Moves {
+			names = append(names, "move/"+path.Base(strings.TrimSuffix(m.Move.URL, "/")))
+		}
+		for _, name := range names {
+			if strings.HasSuffix(name, "/.") || seen[name] {
+				continue
+			}
+			seen[name] = true
+			jobs = append(jobs, apiJob(outDir, api, name))
 		}
 	}
-
-	fmt.Printf("%s sprites download complete!\n", dir)
-}
-
-func parseInt(s string) int {
-	val, _ := strconv.Atoi(s)
-	return val
+	return jobs, nil
 }
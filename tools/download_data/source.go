@@ -0,0 +1,187 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// source is where files come from: a PokeAPI-compatible server, or a local
+// copy of the repositories behind it for offline builds.
+type source interface {
+	read(ctx context.Context, name string) ([]byte, error)
+	String() string
+}
+
+// layout says where a resource lives in a checkout of a PokeAPI repository.
+type layout struct {
+	root string // directory within the repository that names are relative to
+	file func(name string) string
+	keep *regexp.Regexp // files under root worth unpacking from a tarball
+}
+
+var (
+	// PokeAPI/api-data keeps /api/v2/pokemon/25 in data/api/v2/pokemon/25/index.json
+	apiLayout = layout{
+		root: "data/api/v2",
+		file: func(name string) string { return name + "/index.json" },
+		keep: regexp.MustCompile(`^(pokemon|pokemon-species|generation|ability|move|pokedex)/\d+/index\.json$`),
+	}
+	// PokeAPI/sprites mirrors the artwork URLs below sprites/
+	spriteLayout = layout{
+		root: "sprites/pokemon/other/official-artwork",
+		file: func(name string) string { return name },
+		keep: regexp.MustCompile(`^(shiny/)?\d+\.png$`),
+	}
+)
+
+type httpOptions struct {
+	client  *http.Client
+	limiter *tokenBucket
+	retries int
+}
+
+// newSource opens spec, which is a URL, a checkout of the repository (or the
+// directory within it that l.root points at), or a tarball of either. The
+// files of a tarball that l keeps are unpacked to a temporary directory that
+// the returned cleanup removes.
+func newSource(spec string, kind fileKind, l layout, opts httpOptions) (source, func(), error) {
+	noop := func() {}
+	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+		return &httpSource{
+			base:    strings.TrimSuffix(spec, "/"),
+			kind:    kind,
+			client:  opts.client,
+			limiter: opts.limiter,
+			retries: opts.retries,
+		}, noop, nil
+	}
+
+	info, err := os.Stat(spec)
+	if err != nil {
+		return nil, noop, err
+	}
+	if info.IsDir() {
+		return newDirSource(spec, l), noop, nil
+	}
+
+	tmp, err := os.MkdirTemp("", "pokeapi-")
+	if err != nil {
+		return nil, noop, err
+	}
+	cleanup := func() { os.RemoveAll(tmp) }
+	if err := extractTar(spec, tmp, l); err != nil {
+		cleanup()
+		return nil, noop, fmt.Errorf("%s: %w", spec, err)
+	}
+	return &dirSource{root: tmp, label: spec, layout: l}, cleanup, nil
+}
+
+// dirSource reads from a local checkout.
+type dirSource struct {
+	root   string
+	label  string
+	layout layout
+}
+
+func newDirSource(dir string, l layout) *dirSource {
+	root := dir
+	if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(l.root))); err == nil && info.IsDir() {
+		root = filepath.Join(dir, filepath.FromSlash(l.root))
+	}
+	return &dirSource{root: root, label: dir, layout: l}
+}
+
+func (s *dirSource) String() string {
+	return s.label
+}
+
+func (s *dirSource) read(ctx context.Context, name string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Join(s.root, filepath.FromSlash(s.layout.file(name))))
+}
+
+// extractTar unpacks the entries l keeps from a (possibly gzipped) tarball
+// into dir. Entries are matched after l.root wherever it appears, so archives
+// from GitHub, which add a top-level directory, work as they are.
+func extractTar(archive, dir string, l layout) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = bufio.NewReader(f)
+	if magic, _ := r.(*bufio.Reader).Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	found := 0
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		rel, ok := relativeTo(hdr.Name, l.root)
+		if !ok || !l.keep.MatchString(rel) {
+			continue
+		}
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, tr)
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		found++
+	}
+	if found == 0 {
+		return fmt.Errorf("no files under %s", l.root)
+	}
+	return nil
+}
+
+// relativeTo returns name relative to the first occurrence of root in it, or
+// name itself for archives of root's contents.
+func relativeTo(name, root string) (string, bool) {
+	name = path.Clean(strings.TrimPrefix(name, "./"))
+	if name == "." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+	if i := strings.Index("/"+name, "/"+root+"/"); i >= 0 {
+		return name[i+len(root)+1:], true
+	}
+	return name, true
+}
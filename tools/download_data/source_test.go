@@ -0,0 +1,204 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// apiFixture is a slice of PokeAPI/api-data, keyed by path in the repository.
+var apiFixture = map[string]string{
+	"data/api/v2/pokemon/25/index.json":         `{"id": 25, "name": "pikachu"}`,
+	"data/api/v2/pokemon-species/25/index.json": `{"id": 25, "name": "pikachu"}`,
+	"data/api/v2/ability/9/index.json":          `{"id": 9, "name": "static"}`,
+	"data/api/v2/pokemon/index.json":            `{"count": 1}`,
+	"data/api/v2/berry/1/index.json":            `{"id": 1}`,
+	"README.md":                                 "api-data",
+}
+
+// writeTree writes files below dir.
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// writeTarball writes files to a tarball below prefix, gzipped if asked.
+func writeTarball(t *testing.T, path, prefix string, files map[string]string, gzipped bool) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var w io.Writer = f
+	if gzipped {
+		gz := gzip.NewWriter(f)
+		defer gz.Close()
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+
+	if prefix != "" {
+		if err := tw.WriteHeader(&tar.Header{Name: prefix + "/", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range files {
+		if prefix != "" {
+			name = prefix + "/" + name
+		}
+		hdr := &tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// checkSource reads the fixture's Pokemon through src, and a file it lacks.
+func checkSource(t *testing.T, src source) {
+	t.Helper()
+	got, err := src.read(context.Background(), "pokemon/25")
+	if err != nil {
+		t.Fatalf("read pokemon/25: %v", err)
+	}
+	if want := apiFixture["data/api/v2/pokemon/25/index.json"]; string(got) != want {
+		t.Errorf("read pokemon/25 = %q, want %q", got, want)
+	}
+	if _, err := src.read(context.Background(), "ability/9"); err != nil {
+		t.Errorf("read ability/9: %v", err)
+	}
+	if _, err := src.read(context.Background(), "pokemon/26"); !notFound(err) {
+		t.Errorf("read pokemon/26 error = %v, want not found", err)
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	repo := t.TempDir()
+	writeTree(t, repo, apiFixture)
+
+	// A checkout, or the directory within it that the layout's root names
+	for _, dir := range []string{repo, filepath.Join(repo, "data", "api", "v2")} {
+		src, cleanup, err := newSource(dir, kindJSON, apiLayout, httpOptions{})
+		if err != nil {
+			t.Fatalf("newSource(%s): %v", dir, err)
+		}
+		defer cleanup()
+		if src.String() != dir {
+			t.Errorf("source = %s, want %s", src, dir)
+		}
+		checkSource(t, src)
+	}
+}
+
+func TestTarballSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		gzipped bool
+	}{
+		{"tar", "", false},
+		{"gzipped", "", true},
+		{"GitHub archive", "api-data-master", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "api-data.tar.gz")
+			writeTarball(t, archive, tt.prefix, apiFixture, tt.gzipped)
+
+			src, cleanup, err := newSource(archive, kindJSON, apiLayout, httpOptions{})
+			if err != nil {
+				t.Fatalf("newSource: %v", err)
+			}
+			checkSource(t, src)
+
+			// Only the files the layout keeps are unpacked
+			root := src.(*dirSource).root
+			var unpacked []string
+			filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+				if err == nil && !d.IsDir() {
+					rel, _ := filepath.Rel(root, path)
+					unpacked = append(unpacked, filepath.ToSlash(rel))
+				}
+				return err
+			})
+			if len(unpacked) != 3 {
+				t.Errorf("unpacked %v, want the two Pokemon files and the ability", unpacked)
+			}
+
+			cleanup()
+			if _, err := os.Stat(root); !os.IsNotExist(err) {
+				t.Errorf("cleanup left %s behind", root)
+			}
+		})
+	}
+}
+
+func TestTarballSourceWithoutFiles(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "sprites.tar")
+	writeTarball(t, archive, "", map[string]string{"README.md": "sprites"}, false)
+
+	if _, _, err := newSource(archive, kindJSON, apiLayout, httpOptions{}); err == nil {
+		t.Error("newSource accepted a tarball without API data")
+	}
+}
+
+func TestSpriteTarballSource(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "sprites.tar.gz")
+	writeTarball(t, archive, "sprites-master", map[string]string{
+		"sprites/pokemon/other/official-artwork/25.png":       "normal",
+		"sprites/pokemon/other/official-artwork/shiny/25.png": "shiny",
+		"sprites/pokemon/25.png":                              "small",
+	}, true)
+
+	src, cleanup, err := newSource(archive, kindPNG, spriteLayout, httpOptions{})
+	if err != nil {
+		t.Fatalf("newSource: %v", err)
+	}
+	defer cleanup()
+
+	for name, want := range map[string]string{"25.png": "normal", "shiny/25.png": "shiny"} {
+		got, err := src.read(context.Background(), name)
+		if err != nil {
+			t.Errorf("read %s: %v", name, err)
+		} else if string(got) != want {
+			t.Errorf("read %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRelativeTo(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"data/api/v2/pokemon/1/index.json", "pokemon/1/index.json", true},
+		{"api-data-master/data/api/v2/pokemon/1/index.json", "pokemon/1/index.json", true},
+		{"./pokemon/1/index.json", "pokemon/1/index.json", true},
+		{"../pokemon/1/index.json", "", false},
+		{"./", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := relativeTo(tt.name, apiLayout.root)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("relativeTo(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
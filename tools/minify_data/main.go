@@ -1,15 +1,19 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 )
 
 // MinimalStat represents a minimal stat entry
 type MinimalStat struct {
 	BaseStat int `json:"base_stat"`
+	Effort   int `json:"effort,omitempty"`
 	Stat     struct {
 		Name string `json:"name"`
 	} `json:"stat"`
@@ -23,14 +27,24 @@ type MinimalType struct {
 	} `json:"type"`
 }
 
+// MinimalAbilitySlot represents one of a Pokemon's abilities
+type MinimalAbilitySlot struct {
+	IsHidden bool `json:"is_hidden"`
+	Ability  struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"ability"`
+}
+
 // MinimalPokemon contains only the fields we actually use
 type MinimalPokemon struct {
-	ID     int           `json:"id"`
-	Name   string        `json:"name"`
-	Height int           `json:"height"`
-	Weight int           `json:"weight"`
-	Stats  []MinimalStat `json:"stats"`
-	Types  []MinimalType `json:"types"`
+	ID        int                  `json:"id"`
+	Name      string               `json:"name"`
+	Height    int                  `json:"height"`
+	Weight    int                  `json:"weight"`
+	Stats     []MinimalStat        `json:"stats"`
+	Types     []MinimalType        `json:"types"`
+	Abilities []MinimalAbilitySlot `json:"abilities,omitempty"`
 }
 
 // MinimalGeneration for generation files
@@ -43,14 +57,140 @@ type MinimalGeneration struct {
 	} `json:"pokemon_species"`
 }
 
+// MinimalSpecies keeps what links a species to its forms and to the species
+// it evolves from, and its breeding and training data
+type MinimalSpecies struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Varieties []struct {
+		IsDefault bool `json:"is_default"`
+		Pokemon   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"varieties"`
+	EggGroups     []named `json:"egg_groups"`
+	GenderRate    int     `json:"gender_rate"`
+	CaptureRate   int     `json:"capture_rate"`
+	BaseHappiness *int    `json:"base_happiness"`
+	GrowthRate    *named  `json:"growth_rate"`
+	HatchCounter  *int    `json:"hatch_counter"`
+	Habitat       *named  `json:"habitat"`
+	Color         *named  `json:"color"`
+	IsLegendary   bool    `json:"is_legendary,omitempty"`
+	IsMythical    bool    `json:"is_mythical,omitempty"`
+
+	EvolvesFromSpecies *named `json:"evolves_from_species"`
+}
+
+// named is a link to another resource, kept by name only
+type named struct {
+	Name string `json:"name"`
+}
+
+// language is how PokeAPI tags translated text
+type language struct {
+	Name string `json:"name"`
+}
+
+// MinimalAbility keeps the names and short effect of an ability in the
+// languages the app shows
+type MinimalAbility struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []struct {
+		Name     string   `json:"name"`
+		Language language `json:"language"`
+	} `json:"names"`
+	EffectEntries []struct {
+		ShortEffect string   `json:"short_effect"`
+		Language    language `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText string   `json:"flavor_text"`
+		Language   language `json:"language"`
+	} `json:"flavor_text_entries"`
+}
+
+// textLanguages are the languages names and text are kept in
+var textLanguages = map[string]bool{"en": true, "pt-BR": true, "pt": true}
+
+// trim drops text in other languages and all but the newest flavor text of
+// each language.
+func (a *MinimalAbility) trim() {
+	names := a.Names[:0]
+	for _, n := range a.Names {
+		if textLanguages[n.Language.Name] {
+			names = append(names, n)
+		}
+	}
+	a.Names = names
+
+	effects := a.EffectEntries[:0]
+	for _, e := range a.EffectEntries {
+		if textLanguages[e.Language.Name] {
+			effects = append(effects, e)
+		}
+	}
+	a.EffectEntries = effects
+
+	latest := make(map[string]int)
+	for i, f := range a.FlavorTextEntries {
+		if textLanguages[f.Language.Name] {
+			latest[f.Language.Name] = i
+		}
+	}
+	flavors := a.FlavorTextEntries[:0]
+	for i, f := range a.FlavorTextEntries {
+		if idx, ok := latest[f.Language.Name]; ok && idx == i {
+			flavors = append(flavors, f)
+		}
+	}
+	a.FlavorTextEntries = flavors
+}
+
+// MinimalPokedex keeps the names and numbering of a regional dex
+type MinimalPokedex struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	IsMainSeries bool   `json:"is_main_series"`
+	Names        []struct {
+		Name     string   `json:"name"`
+		Language language `json:"language"`
+	} `json:"names"`
+	Region         *named `json:"region"`
+	PokemonEntries []struct {
+		EntryNumber    int `json:"entry_number"`
+		PokemonSpecies struct {
+			URL string `json:"url"`
+		} `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+// MinimalLearnset is the compact learnset written by clean_data, moved to a
+// file of its own so the Pokedex can load without it
+type MinimalLearnset struct {
+	VersionGroups []string `json:"version_groups"`
+	Methods       []string `json:"methods"`
+	Moves         []string `json:"moves"`
+	Entries       [][4]int `json:"entries"`
+}
+
 func main() {
-	inputDir := "assets/api_data"
-	outputDir := "assets/embed/api_data"
+	inputFlag := flag.String("in", "assets/api_data_clean", "directory with the cleaned pokemon_*.json files")
+	outputFlag := flag.String("out", "assets/embed", "directory to write the minified api_data/ into")
+	flag.Parse()
 
-	// Ensure output directory exists
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		fmt.Printf("Error creating output directory: %v\n", err)
-		os.Exit(1)
+	inputDir := *inputFlag
+	outputDir := filepath.Join(*outputFlag, "api_data")
+	learnsetDir := filepath.Join(*outputFlag, "learnsets")
+
+	// Ensure output directories exist
+	for _, dir := range []string{outputDir, learnsetDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			fmt.Printf("Error creating output directory: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
 	// Process Pokemon files
@@ -58,7 +198,18 @@ func main() {
 	totalOrigSize := int64(0)
 	totalMinSize := int64(0)
 
-	for i := 1; i <= 1025; i++ {
+	// Forms have entries of their own, numbered from 10001
+	matches, _ := filepath.Glob(filepath.Join(inputDir, "pokemon_*.json"))
+	var ids []int
+	for _, path := range matches {
+		var id int
+		if _, err := fmt.Sscanf(filepath.Base(path), "pokemon_%d.json", &id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	for _, i := range ids {
 		inputPath := filepath.Join(inputDir, fmt.Sprintf("pokemon_%d.json", i))
 		outputPath := filepath.Join(outputDir, fmt.Sprintf("pokemon_%d.json", i))
 
@@ -112,6 +263,35 @@ func main() {
 			}
 		}
 
+		// Process abilities
+		if abilities, ok := fullData["abilities"].([]interface{}); ok {
+			for _, a := range abilities {
+				slot := a.(map[string]interface{})
+				var ma MinimalAbilitySlot
+				ma.IsHidden, _ = slot["is_hidden"].(bool)
+				if info, ok := slot["ability"].(map[string]interface{}); ok {
+					ma.Ability.Name, _ = info["name"].(string)
+					ma.Ability.URL, _ = info["url"].(string)
+				}
+				minimal.Abilities = append(minimal.Abilities, ma)
+			}
+		}
+
+		// Learnset, where the cleaned data has one
+		if learnset, ok := fullData["learnset"]; ok {
+			var ml MinimalLearnset
+			raw, _ := json.Marshal(learnset)
+			if err := json.Unmarshal(raw, &ml); err != nil {
+				fmt.Printf("Error parsing learnset of pokemon_%d.json: %v\n", i, err)
+			} else {
+				minData, _ := json.Marshal(ml)
+				if err := os.WriteFile(filepath.Join(learnsetDir, fmt.Sprintf("%d.json", i)), minData, 0644); err != nil {
+					fmt.Printf("Error writing learnset %d: %v\n", i, err)
+				}
+				totalMinSize += int64(len(minData))
+			}
+		}
+
 		// Write minified JSON (no indentation for smaller size)
 		minData, err := json.Marshal(minimal)
 		if err != nil {
@@ -160,6 +340,107 @@ func main() {
 		totalMinSize += int64(len(minData))
 	}
 
+	// Species files, where the data has them
+	for i := 1; i <= 1025; i++ {
+		name := fmt.Sprintf("pokemon-species_%d.json", i)
+		data, err := os.ReadFile(filepath.Join(inputDir, name))
+		if err != nil {
+			continue
+		}
+		totalOrigSize += int64(len(data))
+
+		var species MinimalSpecies
+		if err := json.Unmarshal(data, &species); err != nil {
+			fmt.Printf("Error parsing %s: %v\n", name, err)
+			continue
+		}
+		minData, err := json.Marshal(species)
+		if err != nil {
+			fmt.Printf("Error marshaling %s: %v\n", name, err)
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, name), minData, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", name, err)
+			continue
+		}
+		totalMinSize += int64(len(minData))
+	}
+
+	// Ability catalog, where the data has it
+	abilityFiles, _ := filepath.Glob(filepath.Join(inputDir, "ability_*.json"))
+	for _, path := range abilityFiles {
+		name := filepath.Base(path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		totalOrigSize += int64(len(data))
+
+		var ability MinimalAbility
+		if err := json.Unmarshal(data, &ability); err != nil {
+			fmt.Printf("Error parsing %s: %v\n", name, err)
+			continue
+		}
+		ability.trim()
+		minData, err := json.Marshal(ability)
+		if err != nil {
+			fmt.Printf("Error marshaling %s: %v\n", name, err)
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, name), minData, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", name, err)
+			continue
+		}
+		totalMinSize += int64(len(minData))
+	}
+
+	// Regional dexes, where the data has them
+	dexFiles, _ := filepath.Glob(filepath.Join(inputDir, "pokedex_*.json"))
+	for _, path := range dexFiles {
+		name := filepath.Base(path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		totalOrigSize += int64(len(data))
+
+		var dex MinimalPokedex
+		if err := json.Unmarshal(data, &dex); err != nil {
+			fmt.Printf("Error parsing %s: %v\n", name, err)
+			continue
+		}
+		names := dex.Names[:0]
+		for _, n := range dex.Names {
+			if textLanguages[n.Language.Name] {
+				names = append(names, n)
+			}
+		}
+		dex.Names = names
+		minData, err := json.Marshal(dex)
+		if err != nil {
+			fmt.Printf("Error marshaling %s: %v\n", name, err)
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, name), minData, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", name, err)
+			continue
+		}
+		totalMinSize += int64(len(minData))
+	}
+
+	// Move catalog, already reduced to what the app shows by clean_data
+	if data, err := os.ReadFile(filepath.Join(inputDir, "moves.json")); err == nil {
+		totalOrigSize += int64(len(data))
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, data); err != nil {
+			fmt.Printf("Error parsing moves.json: %v\n", err)
+		} else if err := os.WriteFile(filepath.Join(outputDir, "moves.json"), compact.Bytes(), 0644); err != nil {
+			fmt.Printf("Error writing moves.json: %v\n", err)
+		} else {
+			totalMinSize += int64(compact.Len())
+		}
+	}
+
 	fmt.Printf("\n✅ Minification complete!\n")
 	fmt.Printf("   Pokemon processed: %d\n", pokemonCount)
 	fmt.Printf("   Original size: %.2f MB\n", float64(totalOrigSize)/1024/1024)
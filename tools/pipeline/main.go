@@ -0,0 +1,351 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Runs the data tools in order, each reading what the step before it wrote
+// to a staging directory under -work, so no step rewrites its own input.
+// What ran and the hashes of what it wrote go to a manifest, and a step whose
+// inputs and outputs still match it is skipped, so an interrupted run resumes.
+
+type config struct {
+	work    string // staging directories and the manifest
+	out     string // where the app's embedded assets end up
+	source  string // passed on to download when set
+	sprites string
+	force   bool
+}
+
+// localSources lists the download sources that are files or directories, so
+// changing them reruns the download.
+func (c config) localSources() []string {
+	var paths []string
+	for _, spec := range []string{c.source, c.sprites} {
+		if spec != "" && !strings.HasPrefix(spec, "http://") && !strings.HasPrefix(spec, "https://") {
+			paths = append(paths, spec)
+		}
+	}
+	return paths
+}
+
+func (c config) raw(elem ...string) string {
+	return filepath.Join(append([]string{c.work, "raw"}, elem...)...)
+}
+
+type step struct {
+	name    string
+	tool    string // directory under tools/
+	help    string
+	args    func(c config) []string
+	inputs  func(c config) []string
+	outputs func(c config) []string
+}
+
+const curatedMoves = "tools/clean_data/curated_moves.go"
+
+// steps is in run order; curate asks an LLM for every Pokemon and rewrites
+// Go source, so "all" leaves it out and it has to be named.
+var steps = []step{
+	{
+		name: "download",
+		tool: "download_data",
+		help: "fetch the PokeAPI data and sprites",
+		args: func(c config) []string {
+			args := []string{"-out", c.raw()}
+			if c.source != "" {
+				args = append(args, "-source", c.source)
+			}
+			if c.sprites != "" {
+				args = append(args, "-sprites", c.sprites)
+			}
+			return args
+		},
+		inputs: func(c config) []string {
+			return c.localSources()
+		},
+		outputs: func(c config) []string {
+			return []string{c.raw("api_data"), c.raw("sprites")}
+		},
+	},
+	{
+		name: "curate",
+		tool: "curate_moves",
+		help: "pick signature moves into " + curatedMoves,
+		args: func(c config) []string {
+			return []string{"-in", c.raw("api_data"), "-out", curatedMoves, "-cache", filepath.Join(c.work, "curate", "cache.json")}
+		},
+		inputs: func(c config) []string {
+			return []string{c.raw("api_data")}
+		},
+		outputs: func(c config) []string {
+			return []string{curatedMoves}
+		},
+	},
+	{
+		name: "clean",
+		tool: "clean_data",
+		help: "trim the data and keep the curated moves",
+		args: func(c config) []string {
+			return []string{"-in", c.raw("api_data"), "-out", filepath.Join(c.work, "clean")}
+		},
+		inputs: func(c config) []string {
+			// The curated moves are compiled into the tool
+			return []string{c.raw("api_data"), "tools/clean_data"}
+		},
+		outputs: func(c config) []string {
+			return []string{filepath.Join(c.work, "clean")}
+		},
+	},
+	{
+		name: "convert",
+		tool: "convert_sprites",
+		help: "turn the sprites into art and compact PNGs",
+		args: func(c config) []string { return []string{"-in", c.raw("sprites"), "-out", c.out} },
+		inputs: func(c config) []string {
+			return []string{c.raw("sprites")}
+		},
+		outputs: func(c config) []string {
+			return []string{filepath.Join(c.out, "art"), filepath.Join(c.out, "sprites")}
+		},
+	},
+	{
+		name: "minify",
+		tool: "minify_data",
+		help: "keep only the fields the app reads",
+		args: func(c config) []string { return []string{"-in", filepath.Join(c.work, "clean"), "-out", c.out} },
+		inputs: func(c config) []string {
+			return []string{filepath.Join(c.work, "clean")}
+		},
+		outputs: func(c config) []string {
+			return []string{filepath.Join(c.out, "api_data"), filepath.Join(c.out, "learnsets")}
+		},
+	},
+	{
+		name: "index",
+		tool: "build_index",
+		help: "build the Pokedex index",
+		args: func(c config) []string { return []string{"-in", c.out, "-out", filepath.Join(c.out, "pokedex.gob")} },
+		inputs: func(c config) []string {
+			return []string{filepath.Join(c.out, "api_data")}
+		},
+		outputs: func(c config) []string {
+			return []string{filepath.Join(c.out, "pokedex.gob")}
+		},
+	},
+	{
+		name: "bundle",
+		tool: "bundle_assets",
+		help: "pack everything into the embedded bundle",
+		args: func(c config) []string { return []string{"-in", c.out, "-out", filepath.Join(c.out, "bundle.bin")} },
+		inputs: func(c config) []string {
+			return []string{
+				filepath.Join(c.out, "pokedex.gob"),
+				filepath.Join(c.out, "api_data"),
+				filepath.Join(c.out, "learnsets"),
+				filepath.Join(c.out, "art"),
+				filepath.Join(c.out, "sprites"),
+			}
+		},
+		outputs: func(c config) []string {
+			return []string{filepath.Join(c.out, "bundle.bin")}
+		},
+	},
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: go run ./tools/pipeline [flags] <step>...\n\nSteps:\n")
+	for _, s := range steps {
+		fmt.Fprintf(out, "  %-9s %s\n", s.name, s.help)
+	}
+	fmt.Fprintf(out, "  %-9s every step except curate\n", "all")
+	fmt.Fprintf(out, "  %-9s show which steps are up to date\n\nFlags:\n", "status")
+	flag.PrintDefaults()
+}
+
+func main() {
+	var c config
+	flag.StringVar(&c.work, "work", ".pipeline", "directory for the staging directories and manifest")
+	flag.StringVar(&c.out, "out", "assets/embed", "directory for the assets the app embeds")
+	flag.StringVar(&c.source, "source", "", "PokeAPI URL, or checkout or tarball of PokeAPI/api-data, to download from")
+	flag.StringVar(&c.sprites, "sprites", "", "artwork URL, or checkout or tarball of PokeAPI/sprites, to download from")
+	flag.BoolVar(&c.force, "force", false, "run steps even when the manifest says they are up to date")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	// The tools are run with go run, which needs the module root
+	if _, err := os.Stat("go.mod"); err != nil {
+		fmt.Println("Error: run the pipeline from the repository root")
+		os.Exit(1)
+	}
+
+	manifestPath := filepath.Join(c.work, manifestFile)
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		fmt.Printf("Error reading %s: %v\n", manifestPath, err)
+		os.Exit(1)
+	}
+
+	if flag.Arg(0) == "status" {
+		printStatus(c, manifest)
+		return
+	}
+
+	selected, err := selectSteps(flag.Args())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		usage()
+		os.Exit(2)
+	}
+
+	for _, s := range selected {
+		if err := runStep(c, manifest, s); err != nil {
+			// Record the failure so status shows where the run stopped
+			if err := manifest.write(manifestPath); err != nil {
+				fmt.Printf("Error writing %s: %v\n", manifestPath, err)
+			}
+			fmt.Printf("Step %s failed: %v\n", s.name, err)
+			os.Exit(1)
+		}
+		if err := manifest.write(manifestPath); err != nil {
+			fmt.Printf("Error writing %s: %v\n", manifestPath, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// selectSteps resolves the requested names into steps, in run order.
+func selectSteps(names []string) ([]step, error) {
+	want := make(map[string]bool)
+	for _, name := range names {
+		if name == "all" {
+			for _, s := range steps {
+				if s.name != "curate" {
+					want[s.name] = true
+				}
+			}
+			continue
+		}
+		if findStep(name) == nil {
+			return nil, fmt.Errorf("unknown step %q", name)
+		}
+		want[name] = true
+	}
+
+	var selected []step
+	for _, s := range steps {
+		if want[s.name] {
+			selected = append(selected, s)
+		}
+	}
+	return selected, nil
+}
+
+func findStep(name string) *step {
+	for i := range steps {
+		if steps[i].name == name {
+			return &steps[i]
+		}
+	}
+	return nil
+}
+
+func runStep(c config, manifest *Manifest, s step) error {
+	for _, path := range s.inputs(c) {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("missing input %s, run the earlier steps first", path)
+		}
+	}
+	inputs, err := hashPaths(s.inputs(c))
+	if err != nil {
+		return err
+	}
+
+	if !c.force {
+		upToDate, err := manifest.upToDate(s, c, inputs)
+		if err != nil {
+			return err
+		}
+		if upToDate {
+			fmt.Printf("== %s: up to date, skipping\n", s.name)
+			return nil
+		}
+	}
+
+	args := append([]string{"run", "./tools/" + s.tool}, s.args(c)...)
+	fmt.Printf("== %s: go %s\n", s.name, strings.Join(args, " "))
+
+	record := &StepRecord{
+		Tool:    s.tool,
+		Args:    s.args(c),
+		Started: time.Now().UTC().Truncate(time.Second),
+		Inputs:  digest(inputs),
+	}
+	manifest.Steps[s.name] = record
+
+	start := time.Now()
+	cmd := exec.Command("go", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+	record.Duration = time.Since(start).Round(time.Millisecond).String()
+	if runErr != nil {
+		record.Error = runErr.Error()
+		return runErr
+	}
+
+	outputs, err := hashPaths(s.outputs(c))
+	if err != nil {
+		record.Error = err.Error()
+		return err
+	}
+	if len(outputs) == 0 {
+		err := errors.New("wrote no files")
+		record.Error = err.Error()
+		return err
+	}
+	record.OK = true
+	record.Outputs = outputs
+	fmt.Printf("== %s: done in %s, %d files\n", s.name, record.Duration, len(outputs))
+	return nil
+}
+
+func printStatus(c config, manifest *Manifest) {
+	for _, s := range steps {
+		record := manifest.Steps[s.name]
+		state := "not run"
+		switch {
+		case record == nil:
+		case !record.OK:
+			state = "failed: " + record.Error
+		default:
+			inputs, err := hashPaths(s.inputs(c))
+			if err != nil {
+				state = err.Error()
+				break
+			}
+			upToDate, err := manifest.upToDate(s, c, inputs)
+			switch {
+			case err != nil:
+				state = err.Error()
+			case upToDate:
+				state = fmt.Sprintf("up to date (%s, %d files)", record.Started.Local().Format("2006-01-02 15:04"), len(record.Outputs))
+			default:
+				state = "stale, inputs or outputs changed"
+			}
+		}
+		fmt.Printf("%-9s %s\n", s.name, state)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	manifestFile    = "manifest.json"
+	manifestVersion = 1
+)
+
+// Manifest records the last run of every step.
+type Manifest struct {
+	Version int                    `json:"version"`
+	Steps   map[string]*StepRecord `json:"steps"`
+}
+
+// StepRecord is what a step ran with and the SHA-256 of every file it wrote.
+type StepRecord struct {
+	Tool     string            `json:"tool"`
+	Args     []string          `json:"args"`
+	Started  time.Time         `json:"started"`
+	Duration string            `json:"duration"`
+	OK       bool              `json:"ok"`
+	Error    string            `json:"error,omitempty"`
+	Inputs   string            `json:"inputs"` // digest of every input file
+	Outputs  map[string]string `json:"outputs,omitempty"`
+}
+
+func readManifest(path string) (*Manifest, error) {
+	m := &Manifest{Version: manifestVersion, Steps: make(map[string]*StepRecord)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Version != manifestVersion {
+		// Nothing in an old manifest can be trusted, so every step reruns
+		return &Manifest{Version: manifestVersion, Steps: make(map[string]*StepRecord)}, nil
+	}
+	if m.Steps == nil {
+		m.Steps = make(map[string]*StepRecord)
+	}
+	return m, nil
+}
+
+func (m *Manifest) write(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// Written aside and renamed so an interrupted run never leaves half a manifest
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// upToDate reports whether s last succeeded with the same inputs and its
+// outputs are still exactly what it wrote.
+func (m *Manifest) upToDate(s step, c config, inputs map[string]string) (bool, error) {
+	record := m.Steps[s.name]
+	if record == nil || !record.OK || record.Inputs != digest(inputs) {
+		return false, nil
+	}
+	outputs, err := hashPaths(s.outputs(c))
+	if err != nil {
+		return false, err
+	}
+	return len(outputs) > 0 && digest(outputs) == digest(record.Outputs), nil
+}
+
+// hashPaths hashes every regular file under paths, keyed by slash-separated
+// path. Paths that don't exist are left out.
+func hashPaths(paths []string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) && path == root {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			sum, err := hashFile(path)
+			if err != nil {
+				return err
+			}
+			hashes[filepath.ToSlash(path)] = sum
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("hashing %s: %w", root, err)
+		}
+	}
+	return hashes, nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// digest folds a set of file hashes into one, independent of map order.
+func digest(hashes map[string]string) string {
+	paths := make([]string, 0, len(hashes))
+	for path := range hashes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, path := range paths {
+		fmt.Fprintf(h, "%s %s\n", hashes[path], path)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
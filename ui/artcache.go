@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"container/list"
+	"sync"
+)
+
+// artKey identifies one rendering of a sprite, or with baked set the baked
+// half-block art of a Pokemon, which has a single size.
+type artKey struct {
+	id    int
+	shiny bool
+	width int
+	mode  RenderMode
+	baked bool
+}
+
+// artCache is a least-recently-used cache of rendered art, so browsing back
+// and forth does not scale and encode the same sprite again, nor convert the
+// same art to the terminal's colors.
+type artCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is the most recently used
+	entries  map[artKey]*list.Element
+}
+
+type artEntry struct {
+	key artKey
+	art string
+}
+
+func newArtCache(capacity int) *artCache {
+	return &artCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[artKey]*list.Element),
+	}
+}
+
+func (c *artCache) get(key artKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*artEntry).art, true
+}
+
+func (c *artCache) put(key artKey, art string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*artEntry).art = art
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&artEntry{key, art})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*artEntry).key)
+	}
+}
+
+// renderedArt holds the art drawn from sprites during this session.
+var renderedArt = newArtCache(128)
@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"charm-pokemon/models"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// detailPages is how many pages the detail view has: stats and abilities,
+// then breeding and training.
+const detailPages = 2
+
+// openBrowseEggGroup lists the egg groups of pokemon, or every egg group
+// when it is nil.
+func (m *PokedexModel) openBrowseEggGroup(pokemon *models.Pokemon) {
+	m.state = StateBrowseEggGroup
+	m.eggGroupsOf = pokemon
+	m.eggGroupCursor = 0
+	if pokemon != nil {
+		m.eggGroups = nil
+		if pokemon.Species != nil {
+			m.eggGroups = pokemon.Species.EggGroups
+		}
+		return
+	}
+	m.eggGroups = m.pokedex.EggGroupKeys()
+}
+
+func (m PokedexModel) updateBrowseEggGroup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StatePokedexView
+		if m.eggGroupsOf != nil {
+			m.state = StateDetail
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectEggGroup(m.eggGroupCursor)
+	}
+	return m, nil
+}
+
+func (m PokedexModel) updateBrowseEggGroupList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StateBrowseEggGroup
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectListPokemon(m.eggGroupListCursor)
+	}
+	return m, nil
+}
+
+// selectEggGroup lists the Pokemon in the egg group at index, which are the
+// ones that can breed with each other.
+func (m *PokedexModel) selectEggGroup(index int) {
+	if index < 0 || index >= len(m.eggGroups) {
+		return
+	}
+	selected := m.eggGroups[index]
+	m.pokemonList = m.pokedex.GetPokemonByEggGroup(selected)
+	m.eggGroupListCursor = 0
+	if len(m.pokemonList) > 0 {
+		m.selectedEggGroup = selected
+		m.selectedType = ""
+		m.selectedGeneration = 0
+		m.selectedAbility = ""
+		m.selectedMove = ""
+		m.selectedDex = ""
+		m.state = StateBrowseEggGroupList
+	}
+}
+
+func (m PokedexModel) viewBrowseEggGroup() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
+	if len(m.eggGroups) == 0 {
+		s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelNO_BREEDING))
+		s.WriteString("\n\n")
+		s.WriteString(m.helpView())
+		return s.String()
+	}
+
+	startIdx, endIdx := m.visibleRange()
+	for i := startIdx; i < endIdx; i++ {
+		group := m.eggGroups[i]
+		cursor := " "
+		style := getNormalItemStyle()
+		if i == m.eggGroupCursor {
+			cursor = ">"
+			style = getCursorStyle()
+		}
+
+		count := len(m.pokedex.GetPokemonByEggGroup(group))
+		s.WriteString(lipgloss.NewStyle().
+			Align(lipgloss.Left).
+			Width(m.width).
+			Render(style.Render(fmt.Sprintf("%s %-20s - %3d %s", cursor, models.EggGroupName(group), count, LabelPOKEMON))) + "\n")
+	}
+
+	if m.eggGroups[m.eggGroupCursor] == "no-eggs" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelNO_EGGS))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
+
+// viewBreeding renders the second page of the detail view.
+func (m PokedexModel) viewBreeding(pokemon *models.Pokemon) string {
+	var s strings.Builder
+	row := func(label, value string) {
+		s.WriteString(fmt.Sprintf("  %-17s %s\n", label, value))
+	}
+
+	species := pokemon.Species
+	s.WriteString(getLabelStyle().Render(LabelBREEDING))
+	if species != nil && len(species.EggGroups) > 0 {
+		s.WriteString("  ")
+		s.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(fmt.Sprintf("[%s] %s", m.keys.ShowEggGroups.Help().Key, m.keys.ShowEggGroups.Help().Desc)))
+	}
+	s.WriteString("\n")
+
+	if species == nil {
+		s.WriteString(lipgloss.NewStyle().Faint(true).Render("  " + LabelNO_BREEDING))
+		s.WriteString("\n")
+	} else {
+		groups := make([]string, len(species.EggGroups))
+		for i, group := range species.EggGroups {
+			groups[i] = models.EggGroupName(group)
+		}
+		row(LabelEGG_GROUPS, strings.Join(groups, ", "))
+		row(LabelGENDER, genderRatio(species))
+		row(LabelHATCH, fmt.Sprintf(LabelHATCH_CYCLES, species.HatchCounter, species.HatchSteps()))
+	}
+
+	s.WriteString("\n")
+	s.WriteString(getLabelStyle().Render(LabelTRAINING))
+	s.WriteString("\n")
+	row(LabelEV_YIELD, evYield(pokemon.EVYield))
+	if pokemon.BaseExperience > 0 {
+		row(LabelBASE_EXPERIENCE, fmt.Sprint(pokemon.BaseExperience))
+	}
+	if species != nil {
+		row(LabelCAPTURE_RATE, fmt.Sprint(species.CaptureRate))
+		happiness := "—"
+		if species.BaseHappiness >= 0 {
+			happiness = fmt.Sprint(species.BaseHappiness)
+		}
+		row(LabelBASE_HAPPINESS, happiness)
+		row(LabelGROWTH_RATE, nameOr(growthRateNames, species.GrowthRate))
+
+		s.WriteString("\n")
+		s.WriteString(getLabelStyle().Render(LabelSPECIES))
+		s.WriteString("\n")
+		row(LabelHABITAT, nameOr(habitatNames, species.Habitat))
+		row(LabelCOLOR, nameOr(colorNames, species.Color))
+	}
+
+	return s.String()
+}
+
+// genderRatio describes how many of a species are male and female.
+func genderRatio(species *models.Species) string {
+	female := species.FemalePercent()
+	if female < 0 {
+		return LabelGENDERLESS
+	}
+	return fmt.Sprintf("♂ %g%%  ♀ %g%%", 100-female, female)
+}
+
+// evYield lists the effort values a Pokemon gives, e.g. "+2 Veloc.".
+func evYield(stats models.PokemonStats) string {
+	var parts []string
+	for _, stat := range models.AllStats {
+		if value := stats.Get(stat); value > 0 {
+			parts = append(parts, fmt.Sprintf("+%d %s", value, statNames[stat]))
+		}
+	}
+	if len(parts) == 0 {
+		return "—"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// nameOr looks key up in names, falling back to the key itself, or a dash
+// when PokeAPI has no value.
+func nameOr(names map[string]string, key string) string {
+	if key == "" {
+		return "—"
+	}
+	if name, ok := names[key]; ok {
+		return name
+	}
+	return key
+}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"charm-pokemon/models"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The fields of the calculator, in cursor order: the level, the nature,
+// then the IV and EV of each stat.
+const (
+	calcLevel = iota
+	calcNature
+	calcFirstStat
+	calcFields = calcFirstStat + 2*6
+)
+
+// calcField returns the stat of a field and whether it is its EV rather
+// than its IV.
+func calcField(field int) (stat models.Stat, ev bool) {
+	i := field - calcFirstStat
+	return models.AllStats[i/2], i%2 == 1
+}
+
+// openCalculator computes the stats of the Pokemon in the detail view. The
+// spread is kept from the last time, so one set of IVs, EVs and nature can
+// be tried on several Pokemon.
+func (m *PokedexModel) openCalculator() {
+	pokemon := m.shownPokemon()
+	if pokemon == nil {
+		return
+	}
+	m.state = StateStatCalc
+	m.calcOf = pokemon
+}
+
+func (m PokedexModel) updateCalculator(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StateDetail
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Left):
+		m.adjustCalcField(-1)
+
+	case key.Matches(msg, m.keys.Right):
+		m.adjustCalcField(1)
+
+	case key.Matches(msg, m.keys.CalcMin):
+		m.adjustCalcField(-models.MaxLevel * 4)
+
+	case key.Matches(msg, m.keys.CalcMax):
+		m.adjustCalcField(models.MaxLevel * 4)
+
+	case key.Matches(msg, m.keys.CalcReset):
+		m.calcSpread = models.DefaultSpread()
+	}
+	return m, nil
+}
+
+// adjustCalcField changes the field under the cursor by delta steps, within
+// what the games allow. EVs move in steps of 4, the amount that adds a
+// point at level 100, and stop when the total runs out.
+func (m *PokedexModel) adjustCalcField(delta int) {
+	spread := &m.calcSpread
+	switch m.calcCursor {
+	case calcLevel:
+		spread.Level = clamp(spread.Level+delta, 1, models.MaxLevel)
+	case calcNature:
+		if delta != 1 && delta != -1 {
+			return
+		}
+		index := 0
+		for i, nature := range models.Natures {
+			if nature == spread.Nature {
+				index = i
+			}
+		}
+		n := len(models.Natures)
+		spread.Nature = models.Natures[(index+delta+n)%n]
+	default:
+		stat, ev := calcField(m.calcCursor)
+		if !ev {
+			spread.IVs.Set(stat, clamp(spread.IVs.Get(stat)+delta, 0, models.MaxIV))
+			return
+		}
+		current := spread.EVs.Get(stat)
+		left := models.MaxTotalEVs - spread.EVs.Total() + current
+		limit := models.MaxEV
+		if left < limit {
+			limit = left - left%4
+		}
+		spread.EVs.Set(stat, clamp(current+4*delta, 0, limit))
+	}
+}
+
+func clamp(value, low, high int) int {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
+
+// natureLabel names a nature with the stats it raises and lowers.
+func natureLabel(nature models.Nature) string {
+	if nature.Neutral() {
+		return fmt.Sprintf("%s (%s)", nature.NamePT, LabelNEUTRAL)
+	}
+	return fmt.Sprintf("%s (+%s −%s)", nature.NamePT, statNames[nature.Up], statNames[nature.Down])
+}
+
+func (m PokedexModel) viewCalculator() string {
+	var s strings.Builder
+	pokemon := m.calcOf
+	spread := m.calcSpread
+
+	s.WriteString(m.listHeader())
+
+	cell := func(field int, text string) string {
+		if field == m.calcCursor {
+			return getCursorStyle().Render(text)
+		}
+		return text
+	}
+	marker := func(field int) string {
+		if field == m.calcCursor {
+			return ">"
+		}
+		return " "
+	}
+
+	s.WriteString(fmt.Sprintf("%s %-10s %s\n", marker(calcLevel), LabelLEVEL, cell(calcLevel, fmt.Sprintf("◀ %d ▶", spread.Level))))
+	s.WriteString(fmt.Sprintf("%s %-10s %s\n", marker(calcNature), LabelNATURE, cell(calcNature, "◀ "+natureLabel(spread.Nature)+" ▶")))
+	s.WriteString(fmt.Sprintf("  %-10s %d/%d\n\n", LabelEVS_USED, spread.EVs.Total(), models.MaxTotalEVs))
+
+	s.WriteString(getLabelStyle().Render(fmt.Sprintf("  %-8s %5s %4s %4s   %-15s %6s   %-9s %-9s", "", "Base", "IV", "EV", "", "Valor", "Nv. 50", "Nv. 100")))
+	s.WriteString("\n")
+
+	actual := spread.Calc(pokemon.Stats)
+	for i, stat := range models.AllStats {
+		name := statNames[stat]
+		if !spread.Nature.Neutral() && stat != models.StatHP {
+			switch stat {
+			case spread.Nature.Up:
+				name += "+"
+			case spread.Nature.Down:
+				name += "−"
+			}
+		}
+		ivField := calcFirstStat + 2*i
+		base := pokemon.Stats.Get(stat)
+		_, top := models.StatRange(stat, models.MaxBaseStat, spread.Level)
+		min50, max50 := models.StatRange(stat, base, 50)
+		min100, max100 := models.StatRange(stat, base, 100)
+
+		cursor := " "
+		if m.calcCursor == ivField || m.calcCursor == ivField+1 {
+			cursor = ">"
+		}
+		s.WriteString(fmt.Sprintf("%s %-8s %5d %s %s   %s   %-9s %-9s\n",
+			cursor, name, base,
+			cell(ivField, fmt.Sprintf("%4d", spread.IVs.Get(stat))),
+			cell(ivField+1, fmt.Sprintf("%4d", spread.EVs.Get(stat))),
+			renderStatBar(actual.Get(stat), top),
+			fmt.Sprintf("%d-%d", min50, max50),
+			fmt.Sprintf("%d-%d", min100, max100)))
+	}
+
+	s.WriteString("\n")
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelCALC_HINT))
+	s.WriteString("\n\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
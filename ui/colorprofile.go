@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/muesli/termenv"
+)
+
+var (
+	artProfile     termenv.Profile
+	artProfileOnce sync.Once
+)
+
+// colorProfile returns the color profile of the terminal, honoring NO_COLOR
+// and CLICOLOR_FORCE. It is detected once, on first use.
+func colorProfile() termenv.Profile {
+	artProfileOnce.Do(func() {
+		artProfile = termenv.EnvColorProfile()
+	})
+	return artProfile
+}
+
+// asciiRamp maps brightness to characters, from empty to densest.
+const asciiRamp = " .:-=+*#%@"
+
+// adaptArt rewrites the 24-bit color sequences of half-block art for the
+// given profile: down-sampled to 256 or 16 colors, or turned into plain
+// characters by brightness when colors are unavailable.
+func adaptArt(art string, profile termenv.Profile) string {
+	if profile == termenv.TrueColor || !strings.Contains(art, "\x1b[") {
+		return art
+	}
+
+	var out strings.Builder
+	out.Grow(len(art))
+
+	var fg, bg *rgb
+	for i := 0; i < len(art); {
+		if strings.HasPrefix(art[i:], "\x1b[") {
+			end := strings.IndexByte(art[i:], 'm')
+			if end < 0 {
+				break
+			}
+			params := art[i+2 : i+end]
+			i += end + 1
+
+			seq := parseSGR(params, &fg, &bg)
+			if profile != termenv.Ascii {
+				out.WriteString(convertSGR(seq, profile))
+			}
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(art[i:])
+		i += size
+
+		if profile == termenv.Ascii && (r == '▀' || r == '▄') {
+			out.WriteByte(blockToASCII(fg, bg))
+			continue
+		}
+		out.WriteRune(r)
+	}
+
+	return out.String()
+}
+
+type rgb struct {
+	r, g, b int
+}
+
+func (c rgb) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+}
+
+// luminance returns the perceived brightness in [0, 1].
+func (c rgb) luminance() float64 {
+	return (0.2126*float64(c.r) + 0.7152*float64(c.g) + 0.0722*float64(c.b)) / 255
+}
+
+// sgrParam is one attribute of an SGR sequence. Colors keep their RGB value
+// so they can be converted; anything else is passed through as-is.
+type sgrParam struct {
+	raw   string
+	color *rgb
+	bg    bool
+}
+
+// parseSGR splits the parameters of an SGR sequence and tracks the current
+// foreground and background colors.
+func parseSGR(params string, fg, bg **rgb) []sgrParam {
+	fields := strings.Split(params, ";")
+	var seq []sgrParam
+
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "", "0":
+			*fg, *bg = nil, nil
+			seq = append(seq, sgrParam{raw: "0"})
+		case "38", "48":
+			if i+4 < len(fields) && fields[i+1] == "2" {
+				c := &rgb{atoi(fields[i+2]), atoi(fields[i+3]), atoi(fields[i+4])}
+				isBg := fields[i] == "48"
+				if isBg {
+					*bg = c
+				} else {
+					*fg = c
+				}
+				seq = append(seq, sgrParam{color: c, bg: isBg})
+				i += 4
+				continue
+			}
+			seq = append(seq, sgrParam{raw: fields[i]})
+		default:
+			seq = append(seq, sgrParam{raw: fields[i]})
+		}
+	}
+	return seq
+}
+
+func convertSGR(seq []sgrParam, profile termenv.Profile) string {
+	parts := make([]string, 0, len(seq))
+	for _, p := range seq {
+		if p.color == nil {
+			parts = append(parts, p.raw)
+			continue
+		}
+		color := profile.Color(p.color.hex())
+		if color == nil {
+			continue
+		}
+		parts = append(parts, color.Sequence(p.bg))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return termenv.CSI + strings.Join(parts, ";") + "m"
+}
+
+// blockToASCII picks a ramp character for a half-block cell. A lone half
+// block covers half the cell, so it counts for half the brightness.
+func blockToASCII(fg, bg *rgb) byte {
+	var brightness float64
+	switch {
+	case fg != nil && bg != nil:
+		brightness = (fg.luminance() + bg.luminance()) / 2
+	case fg != nil:
+		brightness = fg.luminance() / 2
+	default:
+		brightness = 0.25
+	}
+
+	idx := 1 + int(brightness*float64(len(asciiRamp)-1))
+	if idx >= len(asciiRamp) {
+		idx = len(asciiRamp) - 1
+	}
+	return asciiRamp[idx]
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"charm-pokemon/assets"
+	"github.com/mattn/go-sixel"
+)
+
+// GraphicsSupport lists the image protocols understood by the terminal.
+type GraphicsSupport struct {
+	Kitty bool
+	ITerm bool
+	Sixel bool
+
+	// Size of a character cell in pixels, when the terminal reports it.
+	// Sixel images are drawn in pixels and need it to cover the art area.
+	CellWidth  int
+	CellHeight int
+}
+
+// graphics is the detected support, set once before the program starts.
+var graphics GraphicsSupport
+
+// SetGraphics records the image protocols the terminal supports.
+func SetGraphics(g GraphicsSupport) {
+	graphics = g
+}
+
+// DetectGraphics works out the supported image protocols from the
+// environment and, when attached to a terminal, by querying it directly.
+// It must run before the program takes over the terminal.
+func DetectGraphics() GraphicsSupport {
+	// Multiplexers need their own passthrough sequences, so images are
+	// left to the half-block art there.
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return GraphicsSupport{}
+	}
+
+	g := graphicsFromEnv(os.Getenv)
+	if reply, ok := queryGraphics(250 * time.Millisecond); ok {
+		g.Kitty = g.Kitty || reply.Kitty
+		g.Sixel = g.Sixel || reply.Sixel
+		g.CellWidth, g.CellHeight = reply.CellWidth, reply.CellHeight
+	}
+	return g
+}
+
+func graphicsFromEnv(getenv func(string) string) GraphicsSupport {
+	var g GraphicsSupport
+
+	term := getenv("TERM")
+	if term == "xterm-kitty" || getenv("KITTY_WINDOW_ID") != "" {
+		g.Kitty = true
+	}
+	if strings.Contains(term, "foot") || strings.Contains(term, "mlterm") {
+		g.Sixel = true
+	}
+
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app":
+		g.ITerm = true
+	case "WezTerm":
+		g.Kitty, g.ITerm, g.Sixel = true, true, true
+	case "ghostty":
+		g.Kitty = true
+	case "mintty":
+		g.ITerm, g.Sixel = true, true
+	}
+
+	// Set by iTerm2 and forwarded over ssh, unlike TERM_PROGRAM
+	if getenv("LC_TERMINAL") == "iTerm2" {
+		g.ITerm = true
+	}
+	return g
+}
+
+func (r RenderMode) String() string {
+	switch r {
+	case RenderSixel:
+		return "Sixel"
+	case RenderKitty:
+		return "Kitty"
+	case RenderITerm:
+		return "iTerm2"
+	case RenderQuarterBlock:
+		return "Quarter-block"
+	case RenderBraille:
+		return "Braille"
+	default:
+		return "Half-block"
+	}
+}
+
+// renderModes returns the render modes usable in this terminal, best first.
+// Half-block art always works and leads the text modes.
+func renderModes() []RenderMode {
+	var modes []RenderMode
+	if hasSprites() {
+		if graphics.Kitty {
+			modes = append(modes, RenderKitty)
+		}
+		if graphics.ITerm {
+			modes = append(modes, RenderITerm)
+		}
+		if graphics.Sixel {
+			modes = append(modes, RenderSixel)
+		}
+	}
+	modes = append(modes, RenderHalfBlock)
+	if hasSprites() {
+		modes = append(modes, RenderQuarterBlock, RenderBraille)
+	}
+	return modes
+}
+
+// startMode, when set with ForceRenderMode, replaces the detected best mode.
+var startMode *RenderMode
+
+// renderModeNames maps the names accepted by -render to their modes.
+var renderModeNames = map[string]RenderMode{
+	"kitty":        RenderKitty,
+	"iterm":        RenderITerm,
+	"sixel":        RenderSixel,
+	"halfblock":    RenderHalfBlock,
+	"quarterblock": RenderQuarterBlock,
+	"braille":      RenderBraille,
+}
+
+// ParseRenderMode resolves a render mode by name, as given to -render.
+func ParseRenderMode(name string) (RenderMode, error) {
+	if mode, ok := renderModeNames[strings.ToLower(name)]; ok {
+		return mode, nil
+	}
+	return RenderHalfBlock, fmt.Errorf("modo de imagem desconhecido %q (disponíveis: kitty, iterm, sixel, halfblock, quarterblock, braille)", name)
+}
+
+// ForceRenderMode starts the Pokedex in mode even when detection did not
+// find support for it, e.g. behind a multiplexer. Only modes without the
+// sprites they draw from are refused.
+func ForceRenderMode(mode RenderMode) error {
+	if mode != RenderHalfBlock && !hasSprites() {
+		return fmt.Errorf("o modo %s precisa dos sprites, que não estão incluídos nesta versão", mode)
+	}
+
+	switch mode {
+	case RenderKitty:
+		graphics.Kitty = true
+	case RenderITerm:
+		graphics.ITerm = true
+	case RenderSixel:
+		graphics.Sixel = true
+	}
+	startMode = &mode
+	return nil
+}
+
+// defaultRenderMode is the mode a new Pokedex starts in.
+func defaultRenderMode() RenderMode {
+	if startMode != nil {
+		return *startMode
+	}
+	return renderModes()[0]
+}
+
+// nextRenderMode returns the mode after current in the supported list.
+func nextRenderMode(current RenderMode) RenderMode {
+	modes := renderModes()
+	for i, mode := range modes {
+		if mode == current {
+			return modes[(i+1)%len(modes)]
+		}
+	}
+	return modes[0]
+}
+
+var (
+	spritesAvailable bool
+	spritesOnce      sync.Once
+)
+
+// hasSprites reports whether PNG sprites are embedded; sample builds have none.
+func hasSprites() bool {
+	spritesOnce.Do(func() {
+		spritesAvailable = assets.Exists("sprites")
+	})
+	return spritesAvailable
+}
+
+// kittyChunk is the largest payload the Kitty protocol accepts per escape.
+const kittyChunk = 4096
+
+// kittyImage transmits and displays a PNG with the Kitty graphics protocol.
+// Earlier placements are deleted first, and C=1 keeps the cursor in place.
+func kittyImage(data []byte, cols, rows int) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var b strings.Builder
+	b.WriteString(kittyClear)
+	for i := 0; i < len(encoded); i += kittyChunk {
+		end := min(i+kittyChunk, len(encoded))
+		more := 0
+		if end < len(encoded) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=T,f=100,q=2,C=1,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, encoded[i:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, encoded[i:end])
+		}
+	}
+	return b.String()
+}
+
+// kittyClear deletes every visible Kitty image.
+const kittyClear = "\x1b_Ga=d,d=A,q=2\x1b\\"
+
+// sixelImage draws img with sixel graphics over cols×rows cells. The cursor
+// is saved and restored around it, since terminals move it past the image.
+func sixelImage(img image.Image, cols, rows int) string {
+	cellWidth, cellHeight := graphics.CellWidth, graphics.CellHeight
+	if cellWidth == 0 || cellHeight == 0 {
+		cellWidth, cellHeight = 10, 20
+	}
+
+	var buf bytes.Buffer
+	if err := sixel.NewEncoder(&buf).Encode(scaleImage(img, cols*cellWidth, rows*cellHeight)); err != nil {
+		return ""
+	}
+	return "\x1b7" + buf.String() + "\x1b8"
+}
+
+// itermImage displays a PNG inline with the iTerm2 protocol. The cursor is
+// saved and restored around it, since iTerm2 moves it past the image.
+func itermImage(data []byte, cols, rows int) string {
+	return fmt.Sprintf("\x1b7\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a\x1b8",
+		len(data), cols, rows, base64.StdEncoding.EncodeToString(data))
+}
+
+// kittyImageShown reports whether the current screen shows a Kitty image,
+// which stays on screen until deleted, unlike text.
+func (m PokedexModel) kittyImageShown() bool {
+	return m.renderMode == RenderKitty && m.currentPokemon != nil &&
+		(m.state == StatePokedexView || m.state == StateDetail)
+}
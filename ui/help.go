@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// helpKeys is the help.KeyMap for a single screen, built from the active
+// key bindings so the help always matches the keys that actually work.
+type helpKeys struct {
+	short []key.Binding
+	full  [][]key.Binding
+}
+
+func (h helpKeys) ShortHelp() []key.Binding {
+	return h.short
+}
+
+func (h helpKeys) FullHelp() [][]key.Binding {
+	return h.full
+}
+
+// MenuHelp returns the help for the main and apps menus.
+func (k KeyMap) MenuHelp() help.KeyMap {
+	return helpKeys{
+		short: []key.Binding{k.Up, k.Down, k.Select, k.Quit, k.Help},
+		full: [][]key.Binding{
+			{k.Up, k.Down},
+			{k.Select, k.Quit, k.Help},
+		},
+	}
+}
+
+func (m PokedexModel) helpKeys() help.KeyMap {
+	k := m.keys
+
+	clearFilters := k.ClearFilters
+	clearFilters.SetEnabled(m.filtered())
+
+	switch m.state {
+	case StatePokedexView:
+		return helpKeys{
+			short: []key.Binding{k.Left, k.Right, k.Select, k.Back, k.Help},
+			full: [][]key.Binding{
+				{k.Left, k.Right, k.Select},
+				{k.Search, k.BrowseTypes, k.BrowseGenerations, k.Favorites, k.BrowseAbilities, k.BrowseMoves, k.BrowseEggGroups, k.BrowseRegions, k.Team, clearFilters},
+				{k.ToggleRender, k.Back, k.ForceQuit, k.Help},
+			},
+		}
+	case StateSearch:
+		return helpKeys{
+			short: []key.Binding{k.SearchUp, k.SearchDown, k.SearchSubmit, k.SearchCancel},
+			full: [][]key.Binding{
+				{k.SearchUp, k.SearchDown},
+				{k.SearchSubmit, k.SearchCancel, k.ForceQuit},
+			},
+		}
+	case StateDetail:
+		cycleForm := k.CycleForm
+		cycleForm.SetEnabled(m.currentPokemon != nil && len(m.currentPokemon.Forms) > 0)
+		showAbilities := k.ShowAbilities
+		showAbilities.SetEnabled(m.shownPokemon() != nil && len(m.shownPokemon().Abilities) > 0)
+		showEggGroups := k.ShowEggGroups
+		showEggGroups.SetEnabled(m.shownPokemon() != nil && m.shownPokemon().Species != nil)
+		return helpKeys{
+			short: []key.Binding{k.TogglePage, k.ToggleShiny, k.ToggleFavorite, cycleForm, showAbilities, k.ShowLearnset, k.ShowCalculator, k.Left, k.Right, k.Back, k.Help},
+			full: [][]key.Binding{
+				{k.Left, k.Right, k.TogglePage},
+				{k.ToggleShiny, k.ToggleFavorite, cycleForm, showAbilities, k.ShowLearnset, k.ShowCalculator, showEggGroups, k.AddToTeam},
+				{k.ToggleRender, k.Back, k.ForceQuit, k.Help},
+			},
+		}
+	case StateBrowseMoves:
+		return helpKeys{
+			short: []key.Binding{k.Up, k.Down, k.Select, k.FilterType, k.FilterCategory, k.Back, k.Help},
+			full: [][]key.Binding{
+				{k.Up, k.Down, k.Select},
+				{k.FilterType, k.FilterCategory},
+				{k.Back, k.ForceQuit, k.Help},
+			},
+		}
+	case StateStatCalc:
+		lower, raise := k.Left, k.Right
+		lower.SetHelp(k.Left.Help().Key, "diminuir")
+		raise.SetHelp(k.Right.Help().Key, "aumentar")
+		return helpKeys{
+			short: []key.Binding{k.Up, k.Down, lower, raise, k.CalcMin, k.CalcMax, k.CalcReset, k.Back, k.Help},
+			full: [][]key.Binding{
+				{k.Up, k.Down, lower, raise},
+				{k.CalcMin, k.CalcMax, k.CalcReset},
+				{k.Back, k.ForceQuit, k.Help},
+			},
+		}
+	case StateTeam:
+		return helpKeys{
+			short: []key.Binding{k.Up, k.Down, k.Select, k.ImportTeam, k.ExportTeam, k.RemoveMember, k.RandomTeam, k.Back, k.Help},
+			full: [][]key.Binding{
+				{k.Up, k.Down, k.Select},
+				{k.ImportTeam, k.ExportTeam, k.RemoveMember, k.RandomTeam},
+				{k.Back, k.ForceQuit, k.Help},
+			},
+		}
+	case StateRandomTeam:
+		slot := m.randomCursor >= randomFirstSlot
+		lower, raise := k.Left, k.Right
+		lower.SetHelp(k.Left.Help().Key, "diminuir")
+		raise.SetHelp(k.Right.Help().Key, "aumentar")
+		lower.SetEnabled(!slot)
+		raise.SetEnabled(!slot)
+		reroll, selectSlot := k.RerollSlot, k.Select
+		reroll.SetEnabled(slot)
+		selectSlot.SetEnabled(slot)
+		return helpKeys{
+			short: []key.Binding{k.Up, k.Down, lower, raise, selectSlot, reroll, k.NewTeam, k.SaveTeam, k.Back, k.Help},
+			full: [][]key.Binding{
+				{k.Up, k.Down, lower, raise, selectSlot},
+				{reroll, k.NewTeam, k.SaveTeam},
+				{k.Back, k.ForceQuit, k.Help},
+			},
+		}
+	case StateLearnset:
+		versions := m.learnset != nil && len(m.learnset.VersionGroups) > 1
+		left, right := k.Left, k.Right
+		left.SetEnabled(versions)
+		right.SetEnabled(versions)
+		return helpKeys{
+			short: []key.Binding{k.Up, k.Down, left, right, k.Back, k.Help},
+			full: [][]key.Binding{
+				{k.Up, k.Down, left, right},
+				{k.Back, k.ForceQuit, k.Help},
+			},
+		}
+	default:
+		return helpKeys{
+			short: []key.Binding{k.Up, k.Down, k.Select, k.Back, k.Help},
+			full: [][]key.Binding{
+				{k.Up, k.Down},
+				{k.Select, k.Back, k.ForceQuit, k.Help},
+			},
+		}
+	}
+}
+
+// helpView renders the short help line, or the full help in a box when the
+// overlay is toggled on.
+func (m PokedexModel) helpView() string {
+	h := m.help
+	h.Width = m.width
+	if h.ShowAll {
+		return getBoxStyle().Render(h.View(m.helpKeys()))
+	}
+	return h.View(m.helpKeys())
+}
@@ -0,0 +1,303 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap holds every key binding used by the application. Bindings can be
+// remapped through a JSON config file (see LoadKeyMap).
+type KeyMap struct {
+	// Navigation
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Select    key.Binding
+	Back      key.Binding
+	Quit      key.Binding
+	ForceQuit key.Binding
+	Help      key.Binding
+
+	// Pokedex view
+	Search            key.Binding
+	BrowseTypes       key.Binding
+	BrowseGenerations key.Binding
+	Favorites         key.Binding
+	BrowseAbilities   key.Binding
+	BrowseMoves       key.Binding
+	BrowseEggGroups   key.Binding
+	BrowseRegions     key.Binding
+	Team              key.Binding
+	ClearFilters      key.Binding
+	ToggleRender      key.Binding
+
+	// Detail view
+	ToggleShiny    key.Binding
+	ToggleFavorite key.Binding
+	CycleForm      key.Binding
+	ShowAbilities  key.Binding
+	ShowLearnset   key.Binding
+	TogglePage     key.Binding
+	ShowEggGroups  key.Binding
+	ShowCalculator key.Binding
+	AddToTeam      key.Binding
+
+	// Stat calculator
+	CalcMin   key.Binding
+	CalcMax   key.Binding
+	CalcReset key.Binding
+
+	// Team view
+	ImportTeam   key.Binding
+	ExportTeam   key.Binding
+	RemoveMember key.Binding
+	RandomTeam   key.Binding
+
+	// Random team view
+	RerollSlot key.Binding
+	NewTeam    key.Binding
+	SaveTeam   key.Binding
+
+	// Moves view
+	FilterType     key.Binding
+	FilterCategory key.Binding
+
+	// Search view (printable keys go to the text input)
+	SearchUp     key.Binding
+	SearchDown   key.Binding
+	SearchSubmit key.Binding
+	SearchCancel key.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:        key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "subir")),
+		Down:      key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "descer")),
+		Left:      key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "anterior")),
+		Right:     key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "próximo")),
+		Select:    key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "selecionar")),
+		Back:      key.NewBinding(key.WithKeys("q", "esc"), key.WithHelp("q", "voltar")),
+		Quit:      key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "sair")),
+		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "sair")),
+		Help:      key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "ajuda")),
+
+		Search:            key.NewBinding(key.WithKeys("1"), key.WithHelp("1", "buscar")),
+		BrowseTypes:       key.NewBinding(key.WithKeys("2"), key.WithHelp("2", "tipos")),
+		BrowseGenerations: key.NewBinding(key.WithKeys("3"), key.WithHelp("3", "gerações")),
+		Favorites:         key.NewBinding(key.WithKeys("4"), key.WithHelp("4", "favoritos")),
+		BrowseAbilities:   key.NewBinding(key.WithKeys("5"), key.WithHelp("5", "habilidades")),
+		BrowseMoves:       key.NewBinding(key.WithKeys("6"), key.WithHelp("6", "movimentos")),
+		BrowseEggGroups:   key.NewBinding(key.WithKeys("7"), key.WithHelp("7", "grupos de ovos")),
+		BrowseRegions:     key.NewBinding(key.WithKeys("8"), key.WithHelp("8", "regiões")),
+		Team:              key.NewBinding(key.WithKeys("9"), key.WithHelp("9", "equipa")),
+		ClearFilters:      key.NewBinding(key.WithKeys("0"), key.WithHelp("0", "limpar filtros")),
+		ToggleRender:      key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "modo de imagem")),
+
+		ToggleShiny:    key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "alternar shiny")),
+		ToggleFavorite: key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "favorito")),
+		CycleForm:      key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "forma")),
+		ShowAbilities:  key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "habilidades")),
+		ShowLearnset:   key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "movimentos")),
+		TogglePage:     key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "página")),
+		ShowEggGroups:  key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "grupos de ovos")),
+		ShowCalculator: key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "calculadora")),
+		AddToTeam:      key.NewBinding(key.WithKeys("+"), key.WithHelp("+", "juntar à equipa")),
+
+		CalcMin:   key.NewBinding(key.WithKeys("["), key.WithHelp("[", "mínimo")),
+		CalcMax:   key.NewBinding(key.WithKeys("]"), key.WithHelp("]", "máximo")),
+		CalcReset: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "repor")),
+
+		ImportTeam:   key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "importar")),
+		ExportTeam:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "exportar")),
+		RemoveMember: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "remover")),
+		RandomTeam:   key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "aleatória")),
+
+		RerollSlot: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "trocar")),
+		NewTeam:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "nova equipa")),
+		SaveTeam:   key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "guardar")),
+
+		FilterType:     key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tipo")),
+		FilterCategory: key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "categoria")),
+
+		SearchUp:     key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "subir")),
+		SearchDown:   key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "descer")),
+		SearchSubmit: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "selecionar")),
+		SearchCancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "voltar")),
+	}
+}
+
+// actions maps the config file names to their bindings.
+func (k *KeyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":                 &k.Up,
+		"down":               &k.Down,
+		"left":               &k.Left,
+		"right":              &k.Right,
+		"select":             &k.Select,
+		"back":               &k.Back,
+		"quit":               &k.Quit,
+		"force_quit":         &k.ForceQuit,
+		"help":               &k.Help,
+		"search":             &k.Search,
+		"browse_types":       &k.BrowseTypes,
+		"browse_generations": &k.BrowseGenerations,
+		"favorites":          &k.Favorites,
+		"browse_abilities":   &k.BrowseAbilities,
+		"browse_moves":       &k.BrowseMoves,
+		"browse_egg_groups":  &k.BrowseEggGroups,
+		"browse_regions":     &k.BrowseRegions,
+		"team":               &k.Team,
+		"clear_filters":      &k.ClearFilters,
+		"toggle_render":      &k.ToggleRender,
+		"toggle_shiny":       &k.ToggleShiny,
+		"toggle_favorite":    &k.ToggleFavorite,
+		"cycle_form":         &k.CycleForm,
+		"show_abilities":     &k.ShowAbilities,
+		"show_learnset":      &k.ShowLearnset,
+		"toggle_page":        &k.TogglePage,
+		"show_egg_groups":    &k.ShowEggGroups,
+		"show_calculator":    &k.ShowCalculator,
+		"add_to_team":        &k.AddToTeam,
+		"import_team":        &k.ImportTeam,
+		"export_team":        &k.ExportTeam,
+		"remove_member":      &k.RemoveMember,
+		"random_team":        &k.RandomTeam,
+		"reroll_slot":        &k.RerollSlot,
+		"new_team":           &k.NewTeam,
+		"save_team":          &k.SaveTeam,
+		"calc_min":           &k.CalcMin,
+		"calc_max":           &k.CalcMax,
+		"calc_reset":         &k.CalcReset,
+		"filter_type":        &k.FilterType,
+		"filter_category":    &k.FilterCategory,
+		"search_up":          &k.SearchUp,
+		"search_down":        &k.SearchDown,
+		"search_submit":      &k.SearchSubmit,
+		"search_cancel":      &k.SearchCancel,
+	}
+}
+
+// scopes lists the actions that are active at the same time. Two actions in
+// the same scope must not share a key.
+func (k *KeyMap) scopes() map[string][]string {
+	return map[string][]string{
+		"menu":       {"up", "down", "select", "quit", "help"},
+		"pokedex":    {"left", "right", "select", "back", "force_quit", "help", "search", "browse_types", "browse_generations", "favorites", "browse_abilities", "browse_moves", "browse_egg_groups", "browse_regions", "team", "clear_filters", "toggle_render"},
+		"list":       {"up", "down", "select", "back", "force_quit", "help"},
+		"detail":     {"left", "right", "back", "force_quit", "help", "toggle_shiny", "toggle_favorite", "cycle_form", "show_abilities", "show_learnset", "toggle_page", "show_egg_groups", "show_calculator", "add_to_team", "toggle_render"},
+		"moves":      {"up", "down", "select", "back", "force_quit", "help", "filter_type", "filter_category"},
+		"calculator": {"up", "down", "left", "right", "back", "force_quit", "help", "calc_min", "calc_max", "calc_reset"},
+		"team":       {"up", "down", "select", "back", "force_quit", "help", "import_team", "export_team", "remove_member", "random_team"},
+		"random":     {"up", "down", "left", "right", "select", "back", "force_quit", "help", "reroll_slot", "new_team", "save_team"},
+		"learnset":   {"up", "down", "left", "right", "back", "force_quit", "help"},
+		"search":     {"search_up", "search_down", "search_submit", "search_cancel", "force_quit"},
+	}
+}
+
+// Conflicts returns a description of every key bound to more than one action
+// within the same scope.
+func (k *KeyMap) Conflicts() []string {
+	actions := k.actions()
+	scopes := k.scopes()
+
+	scopeNames := make([]string, 0, len(scopes))
+	for name := range scopes {
+		scopeNames = append(scopeNames, name)
+	}
+	sort.Strings(scopeNames)
+
+	var conflicts []string
+	for _, scope := range scopeNames {
+		owners := make(map[string][]string)
+		var order []string
+		for _, action := range scopes[scope] {
+			for _, pressed := range actions[action].Keys() {
+				if len(owners[pressed]) == 0 {
+					order = append(order, pressed)
+				}
+				owners[pressed] = append(owners[pressed], action)
+			}
+		}
+		for _, pressed := range order {
+			if len(owners[pressed]) > 1 {
+				conflicts = append(conflicts, fmt.Sprintf("%s: tecla %q usada por %s", scope, pressed, strings.Join(owners[pressed], ", ")))
+			}
+		}
+	}
+	return conflicts
+}
+
+// DefaultKeyMapPath returns the config location next to the executable,
+// alongside the favorites file.
+func DefaultKeyMapPath() string {
+	execDir, _ := os.Executable()
+	return filepath.Join(filepath.Dir(execDir), "assets", "keymap.json")
+}
+
+// LoadKeyMap reads key remappings from a JSON file of the form
+// {"up": ["up", "w"], "left": ["left", "a"]}. Actions missing from the file
+// keep their defaults. A missing file is not an error. On any error the
+// default key map is returned alongside it.
+func LoadKeyMap(path string) (KeyMap, error) {
+	keys := DefaultKeyMap()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return keys, nil
+		}
+		return keys, err
+	}
+
+	var config map[string][]string
+	if err := json.Unmarshal(data, &config); err != nil {
+		return keys, fmt.Errorf("%s: %w", path, err)
+	}
+
+	custom := DefaultKeyMap()
+	actions := custom.actions()
+	for action, remapped := range config {
+		binding, ok := actions[action]
+		if !ok {
+			return keys, fmt.Errorf("%s: ação desconhecida %q", path, action)
+		}
+		if len(remapped) == 0 {
+			return keys, fmt.Errorf("%s: ação %q sem teclas", path, action)
+		}
+		binding.SetKeys(remapped...)
+		binding.SetHelp(formatKeys(remapped), binding.Help().Desc)
+	}
+
+	if conflicts := custom.Conflicts(); len(conflicts) > 0 {
+		return keys, fmt.Errorf("%s: conflitos de teclas:\n  %s", path, strings.Join(conflicts, "\n  "))
+	}
+
+	return custom, nil
+}
+
+// formatKeys renders a list of keys for help text, e.g. ["up", "w"] -> "↑/w".
+func formatKeys(keys []string) string {
+	symbols := map[string]string{
+		"up":    "↑",
+		"down":  "↓",
+		"left":  "←",
+		"right": "→",
+		" ":     "espaço",
+	}
+
+	labels := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if symbol, ok := symbols[k]; ok {
+			k = symbol
+		}
+		labels = append(labels, k)
+	}
+	return strings.Join(labels, "/")
+}
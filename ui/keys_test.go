@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDefaultKeyMapHasNoConflicts(t *testing.T) {
+	keys := DefaultKeyMap()
+	if conflicts := keys.Conflicts(); len(conflicts) > 0 {
+		t.Fatalf("default key map has conflicts:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+}
+
+// readmeKeyMap returns the JSON example of the README's key bindings
+// section, so the documented remapping keeps loading as keys are added.
+func readmeKeyMap(t *testing.T) string {
+	t.Helper()
+	readme, err := os.ReadFile(filepath.Join("..", "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, section, ok := strings.Cut(string(readme), "### ⌨️ Custom Key Bindings")
+	if !ok {
+		t.Fatal("README has no custom key bindings section")
+	}
+	_, example, ok := strings.Cut(section, "```json\n")
+	if !ok {
+		t.Fatal("README key bindings section has no JSON example")
+	}
+	example, _, _ = strings.Cut(example, "```")
+	return example
+}
+
+func TestLoadKeyMapREADMEExample(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	if err := os.WriteFile(path, []byte(readmeKeyMap(t)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadKeyMap(path)
+	if err != nil {
+		t.Fatalf("LoadKeyMap: %v", err)
+	}
+
+	w := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")}
+	if !key.Matches(w, keys.Up) {
+		t.Errorf("w does not move up")
+	}
+	x := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+	if !key.Matches(x, keys.ToggleShiny) {
+		t.Errorf("x does not toggle shiny")
+	}
+	if got := keys.Up.Help().Key; got != "↑/w" {
+		t.Errorf("up help = %q, want %q", got, "↑/w")
+	}
+}
+
+func TestLoadKeyMapConflict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	if err := os.WriteFile(path, []byte(`{"toggle_shiny": ["f"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadKeyMap(path)
+	if err == nil || !strings.Contains(err.Error(), "toggle_shiny, toggle_favorite") {
+		t.Fatalf("LoadKeyMap error = %v, want a toggle_shiny/toggle_favorite conflict", err)
+	}
+	if got := keys.ToggleShiny.Keys(); len(got) != 1 || got[0] != "s" {
+		t.Errorf("on error ToggleShiny keys = %v, want the default", got)
+	}
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"charm-pokemon/data"
+	"charm-pokemon/models"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openLearnset shows the moves the Pokemon in the detail view learns, in the
+// newest version group. Learnsets are read from the assets on demand; a
+// form without one of its own shows the species'.
+func (m *PokedexModel) openLearnset() {
+	pokemon := m.shownPokemon()
+	if pokemon == nil {
+		return
+	}
+	learnset, err := data.LoadLearnset(pokemon.ArtID())
+	if err != nil && pokemon.Form != nil {
+		learnset, err = data.LoadLearnset(pokemon.ID)
+	}
+
+	m.state = StateLearnset
+	m.learnsetOf = pokemon
+	m.learnset = learnset
+	m.learnsetErr = err
+	m.learnsetGroup = 0
+	if learnset != nil && len(learnset.VersionGroups) > 0 {
+		m.learnsetGroup = len(learnset.VersionGroups) - 1
+	}
+	m.learnsetScroll = 0
+}
+
+func (m PokedexModel) updateLearnset(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StateDetail
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.scrollLearnset(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.scrollLearnset(1)
+
+	case key.Matches(msg, m.keys.Left):
+		m.cycleVersionGroup(-1)
+
+	case key.Matches(msg, m.keys.Right):
+		m.cycleVersionGroup(1)
+	}
+	return m, nil
+}
+
+// cycleVersionGroup moves to the previous or next version group, wrapping
+// around.
+func (m *PokedexModel) cycleVersionGroup(delta int) {
+	if m.learnset == nil || len(m.learnset.VersionGroups) == 0 {
+		return
+	}
+	n := len(m.learnset.VersionGroups)
+	m.learnsetGroup = (m.learnsetGroup + delta + n) % n
+	m.learnsetScroll = 0
+}
+
+// scrollLearnset scrolls the move list by delta lines, keeping the last
+// page full.
+func (m *PokedexModel) scrollLearnset(delta int) {
+	maxScroll := len(m.learnsetLines()) - m.learnsetPageSize()
+	m.learnsetScroll += delta
+	if m.learnsetScroll > maxScroll {
+		m.learnsetScroll = maxScroll
+	}
+	if m.learnsetScroll < 0 {
+		m.learnsetScroll = 0
+	}
+}
+
+// learnsetPageSize is how many lines of moves fit under the header, the
+// version selector and the help.
+func (m PokedexModel) learnsetPageSize() int {
+	overhead := strings.Count(m.listHeader(), "\n") + 7
+	if size := m.height - overhead; size > 5 {
+		return size
+	}
+	return 5
+}
+
+// learnsetLines lists the moves of the selected version group under a
+// heading per learn method.
+func (m PokedexModel) learnsetLines() []string {
+	if m.learnset == nil || len(m.learnset.VersionGroups) == 0 {
+		return nil
+	}
+	group := m.learnset.VersionGroups[m.learnsetGroup]
+
+	var lines []string
+	for _, method := range models.LearnMethods {
+		moves := m.learnset.ByMethod(group, method)
+		if len(moves) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, getLabelStyle().Render(learnMethodNames[method]))
+		for _, move := range moves {
+			if method != models.LearnLevelUp {
+				lines = append(lines, "  • "+m.pokedex.MoveName(move.Move))
+				continue
+			}
+			level := fmt.Sprintf("Nv. %d", move.Level)
+			if move.Level == 0 {
+				level = LabelEVOLVE_LEVEL
+			}
+			lines = append(lines, fmt.Sprintf("  %-8s %s", level, m.pokedex.MoveName(move.Move)))
+		}
+	}
+	return lines
+}
+
+func (m PokedexModel) viewLearnset() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
+	lines := m.learnsetLines()
+	if len(lines) == 0 {
+		if m.learnsetErr != nil && !errors.Is(m.learnsetErr, fs.ErrNotExist) {
+			s.WriteString(getWarningStyle().Render(fmt.Sprintf("⚠ %v", m.learnsetErr)))
+		} else {
+			s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelNO_LEARNSET))
+		}
+		s.WriteString("\n\n")
+		s.WriteString(m.helpView())
+		return s.String()
+	}
+
+	groups := m.learnset.VersionGroups
+	s.WriteString(fmt.Sprintf("%s %s (%d/%d)\n", LabelVERSION,
+		lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).Render(models.VersionGroupName(groups[m.learnsetGroup])),
+		m.learnsetGroup+1, len(groups)))
+	s.WriteString(fmt.Sprintf("%s   %s\n\n", LabelPREV, LabelNEXT))
+
+	start := m.learnsetScroll
+	end := start + m.learnsetPageSize()
+	if end > len(lines) {
+		end = len(lines)
+	}
+	for _, line := range lines[start:end] {
+		s.WriteString(line)
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf(LabelLINES, start+1, end, len(lines))))
+	s.WriteString("\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
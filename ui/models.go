@@ -4,10 +4,11 @@ import (
 	"charm-pokemon/assets"
 	"charm-pokemon/models"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -23,6 +24,18 @@ const (
 	StateBrowseGenerationList
 	StateFavorites
 	StateDetail
+	StateBrowseAbility
+	StateBrowseAbilityList
+	StateLearnset
+	StateBrowseMoves
+	StateBrowseMoveList
+	StateBrowseEggGroup
+	StateBrowseEggGroupList
+	StateBrowseRegion
+	StateBrowseRegionList
+	StateStatCalc
+	StateTeam
+	StateRandomTeam
 )
 
 type MsgBack struct{}
@@ -32,16 +45,30 @@ type RenderMode int
 const (
 	RenderHalfBlock RenderMode = iota
 	RenderSixel
+	RenderKitty
+	RenderITerm
+	RenderQuarterBlock
+	RenderBraille
 )
 
 type PokedexModel struct {
 	state     PokedexState
 	pokedex   *models.Pokedex
 	favorites *models.FavoritesManager
+	team      *models.TeamManager
 
 	currentPokemon *models.Pokemon
 	showShiny      bool
 
+	// formIndex picks the form of the Pokemon numbered formOf shown in the
+	// detail view: 0 is the default form, i is Forms[i-1].
+	formIndex int
+	formOf    int
+
+	// detailPage is the page of the detail view shown, kept while browsing
+	// so the same data can be compared across Pokemon
+	detailPage int
+
 	searchInput         textinput.Model
 	searchResults       []*models.Pokemon
 	selectedSearchIndex int
@@ -54,19 +81,83 @@ type PokedexModel struct {
 	pokemonList       []*models.Pokemon
 	pokemonListCursor int
 
+	// abilities are the entries of the ability screen: every ability, or
+	// those of abilitiesOf when it was opened from the detail view.
+	abilities         []models.PokemonAbility
+	abilitiesOf       *models.Pokemon
+	abilityCursor     int
+	abilityListCursor int
+
 	selectedType       string
 	selectedGeneration int
+	selectedAbility    string
+	selectedMove       string
+	selectedEggGroup   string
+	selectedDex        string
+
+	regionCursor     int
+	regionListCursor int
+
+	// eggGroups are the entries of the egg group screen: every group, or
+	// those of eggGroupsOf when it was opened from the detail view.
+	eggGroups          []string
+	eggGroupsOf        *models.Pokemon
+	eggGroupCursor     int
+	eggGroupListCursor int
+
+	// moves are the entries of the moves screen, narrowed by moveType and
+	// moveCategory
+	moves          []string
+	moveType       string
+	moveCategory   string
+	movesCursor    int
+	moveListCursor int
+
+	// The stat calculator of calcOf, whose spread outlives the screen
+	calcOf     *models.Pokemon
+	calcSpread models.StatSpread
+	calcCursor int
+
+	// The team screen, with the outcome of the last action on it
+	teamCursor int
+	teamNotice string
+
+	// The random team screen: the constraints, which outlive the screen,
+	// and the team drawn with randomSeed under them
+	randomGeneration    int
+	randomType          string
+	randomUniqueTypes   bool
+	randomMinBST        int
+	randomMaxBST        int
+	randomFullyEvolved  bool
+	randomNoLegendaries bool
+	randomFavorites     bool
+	randomSeed          int64
+	randomGenerator     *models.TeamGenerator
+	randomTeam          []*models.Pokemon
+	randomErr           error
+	randomCursor        int
+
+	// The learnset screen of learnsetOf, scrolled by learnsetScroll lines
+	learnset       *models.Learnset
+	learnsetErr    error
+	learnsetOf     *models.Pokemon
+	learnsetGroup  int
+	learnsetScroll int
 
 	menuCursor int
 
 	renderMode RenderMode
 
+	keys KeyMap
+	help help.Model
+
 	// Terminal dimensions for responsive layout
 	width  int
 	height int
 }
 
-func NewPokedexModel(pokedex *models.Pokedex, favorites *models.FavoritesManager) PokedexModel {
+func NewPokedexModel(pokedex *models.Pokedex, favorites *models.FavoritesManager, team *models.TeamManager, keys KeyMap) PokedexModel {
 	// Initialize current pokemon to the first one in the list
 	var initialPokemon *models.Pokemon
 	if pokedex != nil && len(pokedex.Pokemon) > 0 {
@@ -86,6 +177,7 @@ func NewPokedexModel(pokedex *models.Pokedex, favorites *models.FavoritesManager
 		state:                StatePokedexView,
 		pokedex:              pokedex,
 		favorites:            favorites,
+		team:                 team,
 		currentPokemon:       initialPokemon,
 		showShiny:            false,
 		searchInput:          ti,
@@ -100,6 +192,10 @@ func NewPokedexModel(pokedex *models.Pokedex, favorites *models.FavoritesManager
 		selectedType:         "",
 		selectedGeneration:   0,
 		menuCursor:           0,
+		calcSpread:           models.DefaultSpread(),
+		renderMode:           defaultRenderMode(),
+		keys:                 keys,
+		help:                 help.New(),
 		width:                80, // Default width
 		height:               24, // Default height
 	}
@@ -110,12 +206,31 @@ func (m PokedexModel) Init() tea.Cmd {
 }
 
 func (m PokedexModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Kitty images outlive the text drawn over them, so the screen is
+	// cleared when leaving a screen that showed one.
+	shown := m.kittyImageShown()
+	next, cmd := m.update(msg)
+	if next, ok := next.(PokedexModel); ok && shown && !next.kittyImageShown() {
+		cmd = tea.Batch(cmd, tea.ClearScreen)
+	}
+	return next, cmd
+}
+
+func (m PokedexModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, nil
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
 	case tea.KeyMsg:
+		// The search box takes printable keys, so help is not toggled there
+		if m.state != StateSearch && key.Matches(msg, m.keys.Help) {
+			m.help.ShowAll = !m.help.ShowAll
+			return m, nil
+		}
+
 		switch m.state {
 		case StatePokedexView:
 			return m.updatePokedexView(msg)
@@ -131,6 +246,30 @@ func (m PokedexModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateFavorites(msg)
 		case StateDetail:
 			return m.updateDetail(msg)
+		case StateBrowseAbility:
+			return m.updateBrowseAbility(msg)
+		case StateBrowseAbilityList:
+			return m.updateBrowseAbilityList(msg)
+		case StateLearnset:
+			return m.updateLearnset(msg)
+		case StateBrowseMoves:
+			return m.updateBrowseMoves(msg)
+		case StateBrowseMoveList:
+			return m.updateBrowseMoveList(msg)
+		case StateBrowseEggGroup:
+			return m.updateBrowseEggGroup(msg)
+		case StateBrowseEggGroupList:
+			return m.updateBrowseEggGroupList(msg)
+		case StateBrowseRegion:
+			return m.updateBrowseRegion(msg)
+		case StateBrowseRegionList:
+			return m.updateBrowseRegionList(msg)
+		case StateStatCalc:
+			return m.updateCalculator(msg)
+		case StateTeam:
+			return m.updateTeam(msg)
+		case StateRandomTeam:
+			return m.updateRandomTeam(msg)
 		}
 	}
 	return m, nil
@@ -152,13 +291,37 @@ func (m PokedexModel) View() string {
 		return m.viewFavorites()
 	case StateDetail:
 		return m.viewDetail()
+	case StateBrowseAbility:
+		return m.viewBrowseAbility()
+	case StateBrowseAbilityList:
+		return m.viewBrowseGenerationList()
+	case StateLearnset:
+		return m.viewLearnset()
+	case StateBrowseMoves:
+		return m.viewBrowseMoves()
+	case StateBrowseMoveList:
+		return m.viewBrowseGenerationList()
+	case StateBrowseEggGroup:
+		return m.viewBrowseEggGroup()
+	case StateBrowseEggGroupList:
+		return m.viewBrowseGenerationList()
+	case StateBrowseRegion:
+		return m.viewBrowseRegion()
+	case StateBrowseRegionList:
+		return m.viewBrowseRegionList()
+	case StateStatCalc:
+		return m.viewCalculator()
+	case StateTeam:
+		return m.viewTeam()
+	case StateRandomTeam:
+		return m.viewRandomTeam()
 	default:
 		return "Estado desconhecido"
 	}
 }
 
 func (m PokedexModel) viewPokedex() string {
-	pokemon := m.GetCurrentPokemon()
+	pokemon := m.shownPokemon()
 
 	var s strings.Builder
 
@@ -167,6 +330,14 @@ func (m PokedexModel) viewPokedex() string {
 		title += fmt.Sprintf(" [%s %s]", getTypeEmoji(m.selectedType), m.selectedType)
 	} else if m.selectedGeneration > 0 {
 		title += fmt.Sprintf(" [Gen %d]", m.selectedGeneration)
+	} else if m.selectedAbility != "" {
+		title += fmt.Sprintf(" [%s]", m.pokedex.AbilityName(m.selectedAbility))
+	} else if m.selectedMove != "" {
+		title += fmt.Sprintf(" [%s]", m.pokedex.MoveName(m.selectedMove))
+	} else if m.selectedEggGroup != "" {
+		title += fmt.Sprintf(" [%s]", models.EggGroupName(m.selectedEggGroup))
+	} else if dex := m.pokedex.GetRegionalDex(m.selectedDex); dex != nil {
+		title += fmt.Sprintf(" [%s]", dex.Name())
 	}
 
 	s.WriteString(getBoxStyle().Render(
@@ -177,27 +348,7 @@ func (m PokedexModel) viewPokedex() string {
 	))
 
 	if pokemon != nil {
-		art := m.loadPokemonArt(pokemon)
-
-		// Calculate appropriate width - use terminal width or default
-		artWidth := 65
-		if m.width > 0 && m.width < artWidth+10 {
-			artWidth = m.width - 10
-		}
-
-		// Apply type-based coloring ONLY if art is not already colored (Braille legacy)
-		artStyle := lipgloss.NewStyle().
-			Align(lipgloss.Center).
-			Width(artWidth)
-
-		// If the art doesn't contain ANSI color codes, apply type color
-		if !strings.Contains(art, "\x1b[") && len(pokemon.Types) > 0 {
-			artStyle = artStyle.Foreground(getTypeColor(pokemon.Types[0]))
-		}
-
-		s.WriteString("\n\n")
-		s.WriteString(artStyle.Render(art))
-		s.WriteString("\n\n")
+		var below strings.Builder
 
 		// Show all type emojis
 		typeEmojis := ""
@@ -205,34 +356,17 @@ func (m PokedexModel) viewPokedex() string {
 			typeEmojis += getTypeEmoji(t) + " "
 		}
 
-		s.WriteString(lipgloss.NewStyle().
+		below.WriteString(lipgloss.NewStyle().
 			Align(lipgloss.Center).
 			Width(m.width).
-			Render(fmt.Sprintf("#%d %s %s\n", pokemon.ID, pokemon.NamePT, typeEmojis)))
-		s.WriteString("\n")
-
-		menuItems := []struct {
-			label  string
-			hotkey string
-		}{
-			{LabelSEARCH, "1"},
-			{LabelBROWSE_TYPES, "2"},
-			{LabelBROWSE_GEN, "3"},
-			{LabelFAVORITES, "4"},
-		}
-
-		if m.selectedType != "" || m.selectedGeneration > 0 {
-			menuItems = append(menuItems, struct {
-				label  string
-				hotkey string
-			}{"Limpar Filtros", "0"})
-		}
+			Render(fmt.Sprintf("%s %s %s\n", m.dexNumber(pokemon), pokemon.NamePT, typeEmojis)))
+		below.WriteString("\n")
 
 		// Calculate max width for menu alignment
 		maxWidth := 0
 		var menuStrings []string
-		for _, item := range menuItems {
-			str := fmt.Sprintf("[%s] %s", item.hotkey, item.label)
+		for _, item := range m.pokedexMenu() {
+			str := item.String()
 			if len(str) > maxWidth {
 				maxWidth = len(str)
 			}
@@ -240,58 +374,239 @@ func (m PokedexModel) viewPokedex() string {
 		}
 
 		for _, str := range menuStrings {
-			s.WriteString(lipgloss.NewStyle().
+			below.WriteString(lipgloss.NewStyle().
 				Align(lipgloss.Left).
 				Width(m.width).
 				PaddingLeft((m.width-maxWidth)/2).
 				Render(str) + "\n")
 		}
 
-		s.WriteString("\n")
-		s.WriteString(lipgloss.NewStyle().
+		below.WriteString("\n")
+		below.WriteString(lipgloss.NewStyle().
 			Align(lipgloss.Center).
 			Width(m.width).
-			Render(fmt.Sprintf("%s   Enter para detalhes   %s", LabelPREV, LabelNEXT)))
-		s.WriteString("\n\n")
+			Render(fmt.Sprintf("%s   %s   %s", LabelPREV, LabelDETAILS_HINT, LabelNEXT)))
+		below.WriteString("\n\n")
 
-		s.WriteString(lipgloss.NewStyle().
+		below.WriteString(lipgloss.NewStyle().
 			Align(lipgloss.Center).
 			Width(m.width).
-			Faint(true).
-			Render(LabelPRESS_Q))
+			Render(m.helpView()))
+
+		// The art gets the rows left by the text around it and the blank
+		// line on either side
+		reserved := lipgloss.Height(s.String()) + 2 + lipgloss.Height(below.String())
+		s.WriteString("\n\n")
+		s.WriteString(m.renderArt(pokemon, reserved))
+		s.WriteString("\n\n")
+		s.WriteString(below.String())
 	}
 
 	return s.String()
 }
 
-func (m PokedexModel) viewSearch() string {
+// listHeader renders everything above the items of a list screen. The mouse
+// handler uses its height to map clicks to items.
+func (m PokedexModel) listHeader() string {
+	title := ""
+	switch m.state {
+	case StateSearch:
+		title = LabelSEARCH
+	case StateBrowseType:
+		title = LabelTYPES
+	case StateBrowseGeneration:
+		title = LabelGENERATIONS
+	case StateBrowseGenerationList:
+		currentGen := Generations[m.generationCursor]
+		title = fmt.Sprintf("%s - %s", currentGen.NamePT, currentGen.Region)
+	case StateFavorites:
+		title = LabelFAVORITES
+	case StateBrowseAbility:
+		title = LabelABILITIES_ALL
+		if m.abilitiesOf != nil {
+			title = fmt.Sprintf(LabelABILITIES_OF, m.abilitiesOf.NamePT)
+		}
+	case StateBrowseAbilityList:
+		title = fmt.Sprintf(LabelABILITY, m.pokedex.AbilityName(m.selectedAbility))
+	case StateLearnset:
+		title = fmt.Sprintf(LabelLEARNSET_OF, m.learnsetOf.NamePT)
+	case StateBrowseMoves:
+		title = LabelMOVES_ALL
+	case StateBrowseMoveList:
+		title = fmt.Sprintf(LabelMOVE, m.pokedex.MoveName(m.selectedMove))
+	case StateBrowseEggGroup:
+		title = LabelEGG_GROUPS_ALL
+		if m.eggGroupsOf != nil {
+			title = fmt.Sprintf(LabelEGG_GROUPS_OF, m.eggGroupsOf.NamePT)
+		}
+	case StateBrowseEggGroupList:
+		title = fmt.Sprintf(LabelEGG_GROUP, models.EggGroupName(m.selectedEggGroup))
+	case StateStatCalc:
+		title = fmt.Sprintf(LabelCALCULATOR_OF, m.calcOf.NamePT)
+	case StateTeam:
+		title = fmt.Sprintf(LabelTEAM_OF, len(m.team.Members), models.MaxTeamSize)
+	case StateRandomTeam:
+		title = LabelRANDOM_TEAM_TITLE
+	case StateBrowseRegion:
+		title = LabelREGIONS_ALL
+	case StateBrowseRegionList:
+		if dex := m.pokedex.GetRegionalDex(m.selectedDex); dex != nil {
+			title = fmt.Sprintf(LabelREGIONAL_DEX, dex.Name())
+		}
+	}
+
 	var s strings.Builder
 
 	s.WriteString(getBoxStyle().Render(
 		lipgloss.JoinVertical(lipgloss.Center,
-			getTitleStyle().Render(LabelSEARCH),
+			getTitleStyle().Render(title),
 			"",
 		),
 	))
 
-	s.WriteString("\n")
-	s.WriteString(getLabelStyle().Render(LabelSEARCH_QUERY))
-	s.WriteString("\n")
+	if m.state == StateSearch {
+		s.WriteString("\n")
+		s.WriteString(getLabelStyle().Render(LabelSEARCH_QUERY))
+		s.WriteString("\n")
 
-	s.WriteString(m.searchInput.View())
-	s.WriteString("\n\n")
+		s.WriteString(m.searchInput.View())
+		s.WriteString("\n\n")
+
+		s.WriteString(getLabelStyle().Render(LabelRESULTS))
+	}
 
-	s.WriteString(getLabelStyle().Render(LabelRESULTS))
 	s.WriteString("\n\n")
 
+	if m.state == StateBrowseMoves {
+		s.WriteString(m.movesHeader())
+	}
+
+	return s.String()
+}
+
+// listState returns the cursor, item count and page size of the active list
+// screen.
+func (m PokedexModel) listState() (cursor, count, pageSize int) {
+	switch m.state {
+	case StateSearch:
+		return m.selectedSearchIndex, len(m.searchResults), 8
+	case StateBrowseType:
+		return m.typeCursor, len(TypeNames), len(TypeNames)
+	case StateBrowseGeneration:
+		return m.generationCursor, len(Generations), len(Generations)
+	case StateBrowseGenerationList:
+		return m.generationListCursor, len(m.pokemonList), 10
+	case StateFavorites:
+		return m.favoritesCursor, len(m.pokemonList), 10
+	case StateBrowseAbility:
+		return m.abilityCursor, len(m.abilities), 10
+	case StateBrowseAbilityList:
+		return m.abilityListCursor, len(m.pokemonList), 10
+	case StateBrowseMoves:
+		return m.movesCursor, len(m.moves), 10
+	case StateBrowseMoveList:
+		return m.moveListCursor, len(m.pokemonList), 10
+	case StateBrowseEggGroup:
+		return m.eggGroupCursor, len(m.eggGroups), 10
+	case StateBrowseEggGroupList:
+		return m.eggGroupListCursor, len(m.pokemonList), 10
+	case StateBrowseRegion:
+		return m.regionCursor, len(m.pokedex.RegionalDexes), 10
+	case StateBrowseRegionList:
+		return m.regionListCursor, len(m.pokemonList), 10
+	case StateTeam:
+		return m.teamCursor, len(m.team.Members), models.MaxTeamSize
+	}
+	return 0, 0, 0
+}
+
+// visibleRange returns the [start, end) indexes of the list items on screen,
+// scrolled so the cursor stays visible.
+func (m PokedexModel) visibleRange() (int, int) {
+	cursor, count, pageSize := m.listState()
+	start := 0
+	if cursor >= pageSize {
+		start = cursor - pageSize + 1
+	}
+	end := start + pageSize
+	if end > count {
+		end = count
+	}
+	return start, end
+}
+
+type pokedexMenuItem struct {
+	label  string
+	hotkey key.Binding
+	action func(PokedexModel) (tea.Model, tea.Cmd)
+}
+
+// String is the entry as drawn in the menu, e.g. "[1] 🔍 Buscar".
+func (item pokedexMenuItem) String() string {
+	return fmt.Sprintf("[%s] %s", item.hotkey.Help().Key, item.label)
+}
+
+// pokedexMenu lists the entries shown under the Pokemon in the main Pokedex
+// screen. Clicking an entry runs the same action as its hotkey.
+func (m PokedexModel) pokedexMenu() []pokedexMenuItem {
+	items := []pokedexMenuItem{
+		{LabelSEARCH, m.keys.Search, PokedexModel.openSearch},
+		{LabelBROWSE_TYPES, m.keys.BrowseTypes, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.openBrowseType()
+			return m, nil
+		}},
+		{LabelBROWSE_GEN, m.keys.BrowseGenerations, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.openBrowseGeneration()
+			return m, nil
+		}},
+		{LabelFAVORITES, m.keys.Favorites, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.openFavorites()
+			return m, nil
+		}},
+		{LabelBROWSE_ABILITY, m.keys.BrowseAbilities, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.openBrowseAbility(nil)
+			return m, nil
+		}},
+		{LabelBROWSE_MOVES, m.keys.BrowseMoves, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.openBrowseMoves()
+			return m, nil
+		}},
+		{LabelBROWSE_EGGS, m.keys.BrowseEggGroups, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.openBrowseEggGroup(nil)
+			return m, nil
+		}},
+		{LabelBROWSE_REGIONS, m.keys.BrowseRegions, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.openBrowseRegion()
+			return m, nil
+		}},
+	}
+
+	if m.team != nil {
+		items = append(items, pokedexMenuItem{LabelTEAM, m.keys.Team, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.openTeam()
+			return m, nil
+		}})
+	}
+
+	if m.filtered() {
+		items = append(items, pokedexMenuItem{LabelCLEAR_FILTERS, m.keys.ClearFilters, func(m PokedexModel) (tea.Model, tea.Cmd) {
+			m.clearFilters()
+			return m, nil
+		}})
+	}
+
+	return items
+}
+
+func (m PokedexModel) viewSearch() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
 	if len(m.searchResults) > 0 {
-		maxResults := 8
-		startIdx := 0
-		if m.selectedSearchIndex >= maxResults {
-			startIdx = m.selectedSearchIndex - maxResults + 1
-		}
+		startIdx, endIdx := m.visibleRange()
 
-		for i := startIdx; i < len(m.searchResults) && i < startIdx+maxResults; i++ {
+		for i := startIdx; i < endIdx; i++ {
 			pokemon := m.searchResults[i]
 			cursor := " "
 			if i == m.selectedSearchIndex {
@@ -319,7 +634,7 @@ func (m PokedexModel) viewSearch() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelPRESS_ENTER))
+	s.WriteString(m.helpView())
 
 	return s.String()
 }
@@ -327,14 +642,7 @@ func (m PokedexModel) viewSearch() string {
 func (m PokedexModel) viewBrowseType() string {
 	var s strings.Builder
 
-	s.WriteString(getBoxStyle().Render(
-		lipgloss.JoinVertical(lipgloss.Center,
-			getTitleStyle().Render(LabelTYPES),
-			"",
-		),
-	))
-
-	s.WriteString("\n\n")
+	s.WriteString(m.listHeader())
 
 	for i, typeName := range TypeNames {
 		cursor := " "
@@ -357,7 +665,7 @@ func (m PokedexModel) viewBrowseType() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelPRESS_ENTER))
+	s.WriteString(m.helpView())
 
 	return s.String()
 }
@@ -365,14 +673,7 @@ func (m PokedexModel) viewBrowseType() string {
 func (m PokedexModel) viewBrowseGeneration() string {
 	var s strings.Builder
 
-	s.WriteString(getBoxStyle().Render(
-		lipgloss.JoinVertical(lipgloss.Center,
-			getTitleStyle().Render(LabelGENERATIONS),
-			"",
-		),
-	))
-
-	s.WriteString("\n\n")
+	s.WriteString(m.listHeader())
 
 	for i, gen := range Generations {
 		cursor := " "
@@ -394,40 +695,29 @@ func (m PokedexModel) viewBrowseGeneration() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelPRESS_ENTER))
+	s.WriteString(m.helpView())
 
 	return s.String()
 }
 
+// viewBrowseGenerationList renders the Pokemon of a generation, or those
+// with an ability, learning a move or in an egg group.
 func (m PokedexModel) viewBrowseGenerationList() string {
 	var s strings.Builder
 
-	currentGen := Generations[m.generationCursor]
-
-	s.WriteString(getBoxStyle().Render(
-		lipgloss.JoinVertical(lipgloss.Center,
-			getTitleStyle().Render(fmt.Sprintf("%s - %s", currentGen.NamePT, currentGen.Region)),
-			"",
-		),
-	))
-
-	s.WriteString("\n\n")
-
-	maxResults := 10
-	startIdx := 0
-	if m.generationListCursor >= maxResults {
-		startIdx = m.generationListCursor - maxResults + 1
-	}
+	s.WriteString(m.listHeader())
 
-	for i := startIdx; i < len(m.pokemonList) && i < startIdx+maxResults; i++ {
+	selected, _, _ := m.listState()
+	startIdx, endIdx := m.visibleRange()
+	for i := startIdx; i < endIdx; i++ {
 		pokemon := m.pokemonList[i]
 		cursor := " "
-		if i == m.generationListCursor {
+		if i == selected {
 			cursor = ">"
 		}
 
 		style := getNormalItemStyle()
-		if i == m.generationListCursor {
+		if i == selected {
 			style = getCursorStyle()
 		}
 
@@ -443,31 +733,68 @@ func (m PokedexModel) viewBrowseGenerationList() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelPRESS_ENTER))
+	s.WriteString(m.helpView())
 
 	return s.String()
 }
 
-func (m PokedexModel) viewFavorites() string {
+func (m PokedexModel) viewBrowseAbility() string {
 	var s strings.Builder
 
-	s.WriteString(getBoxStyle().Render(
-		lipgloss.JoinVertical(lipgloss.Center,
-			getTitleStyle().Render(LabelFAVORITES),
-			"",
-		),
-	))
+	s.WriteString(m.listHeader())
 
-	s.WriteString("\n\n")
+	if len(m.abilities) == 0 {
+		s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelNO_ABILITIES))
+		s.WriteString("\n\n")
+		s.WriteString(m.helpView())
+		return s.String()
+	}
 
-	if len(m.pokemonList) > 0 {
-		maxResults := 10
-		startIdx := 0
-		if m.favoritesCursor >= maxResults {
-			startIdx = m.favoritesCursor - maxResults + 1
+	startIdx, endIdx := m.visibleRange()
+	for i := startIdx; i < endIdx; i++ {
+		ability := m.abilities[i]
+		cursor := " "
+		if i == m.abilityCursor {
+			cursor = ">"
+		}
+
+		style := getNormalItemStyle()
+		if i == m.abilityCursor {
+			style = getCursorStyle()
 		}
 
-		for i := startIdx; i < len(m.pokemonList) && i < startIdx+maxResults; i++ {
+		name := m.pokedex.AbilityName(ability.Key)
+		if ability.Hidden {
+			name += " " + LabelHIDDEN_ABILITY
+		}
+		count := len(m.pokedex.GetPokemonByAbility(ability.Key))
+
+		s.WriteString(lipgloss.NewStyle().
+			Align(lipgloss.Left).
+			Width(m.width).
+			Render(style.Render(fmt.Sprintf("%s %-28s - %3d %s", cursor, name, count, LabelPOKEMON))) + "\n")
+	}
+
+	if effect := m.pokedex.AbilityEffect(m.abilities[m.abilityCursor].Key); effect != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Faint(true).Width(m.width).Render(effect))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
+
+func (m PokedexModel) viewFavorites() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
+	if len(m.pokemonList) > 0 {
+		startIdx, endIdx := m.visibleRange()
+		for i := startIdx; i < endIdx; i++ {
 			pokemon := m.pokemonList[i]
 			cursor := " "
 			if i == m.favoritesCursor {
@@ -498,45 +825,53 @@ func (m PokedexModel) viewFavorites() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelPRESS_ENTER))
+	s.WriteString(m.helpView())
 
 	return s.String()
 }
 
 func (m PokedexModel) viewDetail() string {
-	pokemon := m.GetCurrentPokemon()
+	pokemon := m.shownPokemon()
 	if pokemon == nil {
 		return "Nenhum Pokémon selecionado"
 	}
 
 	var s strings.Builder
 
-	art := m.loadPokemonArt(pokemon)
-
 	typeEmojis := ""
 	for _, t := range pokemon.Types {
 		typeEmojis += getTypeEmoji(t) + " "
 	}
 
-	s.WriteString(getHeaderStyle().Render(fmt.Sprintf("#%d %s %s", pokemon.ID, pokemon.NamePT, typeEmojis)))
+	header := getHeaderStyle().Render(fmt.Sprintf("%s %s %s", m.dexNumber(pokemon), pokemon.NamePT, typeEmojis))
+	s.WriteString(header)
 	s.WriteString("\n")
 
-	// Calculate appropriate width - use terminal width or default
-	artWidth := 65
-	if m.width > 0 && m.width < artWidth+10 {
-		artWidth = m.width - 10
-	}
-
-	// Apply type-based coloring ONLY if art is not already colored
-	artStyle := lipgloss.NewStyle().
-		Align(lipgloss.Center).
-		Width(artWidth)
-	if !strings.Contains(art, "\x1b[") && len(pokemon.Types) > 0 {
-		artStyle = artStyle.Foreground(getTypeColor(pokemon.Types[0]))
-	}
+	body := m.viewDetailBody(pokemon)
 
-	s.WriteString(artStyle.Render(art))
+	// The art gets the rows left by the header, the body and the blank line
+	// between the art and the body
+	reserved := lipgloss.Height(header) + 1 + lipgloss.Height(body)
+	s.WriteString(m.renderArt(pokemon, reserved))
 	s.WriteString("\n\n")
+	s.WriteString(body)
+
+	return s.String()
+}
+
+// viewDetailBody renders the detail view below the art.
+func (m PokedexModel) viewDetailBody(pokemon *models.Pokemon) string {
+	var s strings.Builder
+
+	if forms := pokemon.Forms; len(forms) > 0 {
+		kind := LabelDEFAULT_FORM
+		if pokemon.Form != nil {
+			kind = formKindNames[pokemon.Form.Kind]
+		}
+		s.WriteString(fmt.Sprintf("%s %s (%d/%d)  ", LabelFORM, kind, m.shownFormIndex()+1, len(forms)+1))
+		s.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(fmt.Sprintf("[%s] %s", m.keys.CycleForm.Help().Key, m.keys.CycleForm.Help().Desc)))
+		s.WriteString("\n\n")
+	}
 
 	s.WriteString(lipgloss.NewStyle().Render(fmt.Sprintf("%s %.1fm   %s %.1fkg", LabelHEIGHT, pokemon.Height/10.0, LabelWEIGHT, pokemon.Weight/10.0)))
 
@@ -546,7 +881,7 @@ func (m PokedexModel) viewDetail() string {
 	}
 
 	s.WriteString("  ")
-	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(LabelTOGGLE_FAVORITE))
+	s.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(LabelTOGGLE_FAVORITE))
 	s.WriteString(favStatus)
 	s.WriteString("\n\n")
 
@@ -554,33 +889,52 @@ func (m PokedexModel) viewDetail() string {
 	normalStyle := lipgloss.NewStyle()
 	shinyStyle := lipgloss.NewStyle()
 	if m.showShiny {
-		shinyStyle = shinyStyle.Bold(true).Foreground(lipgloss.Color("226"))
+		shinyStyle = shinyStyle.Bold(true).Foreground(theme.Accent)
 		s.WriteString(fmt.Sprintf("[ %s ]  [%s %s] ◄", normalStyle.Render(LabelNORMAL), shinyStyle.Render(LabelSHINY), ""))
 	} else {
-		normalStyle = normalStyle.Bold(true).Foreground(lipgloss.Color("39"))
+		normalStyle = normalStyle.Bold(true).Foreground(theme.Primary)
 		s.WriteString(fmt.Sprintf("◄ [%s]  [ %s ]", normalStyle.Render(LabelNORMAL), shinyStyle.Render(LabelSHINY)))
 	}
+	s.WriteString("\n")
+	s.WriteString(fmt.Sprintf("%s   %s", LabelPREV, LabelNEXT))
+	s.WriteString("\n")
+	s.WriteString(fmt.Sprintf(LabelPAGE, m.detailPage+1, detailPages))
+	s.WriteString("  ")
+	s.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(fmt.Sprintf("[%s] %s", m.keys.TogglePage.Help().Key, m.keys.TogglePage.Help().Desc)))
 	s.WriteString("\n\n")
 
+	if m.detailPage == 1 {
+		s.WriteString(m.viewBreeding(pokemon))
+		s.WriteString("\n")
+		s.WriteString(m.helpView())
+		return s.String()
+	}
+
 	s.WriteString(getLabelStyle().Render(LabelSTATS))
+	s.WriteString("  ")
+	s.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(fmt.Sprintf("[%s] %s", m.keys.ShowCalculator.Help().Key, m.keys.ShowCalculator.Help().Desc)))
 	s.WriteString("\n")
 
-	stats := []struct {
-		name  string
-		value int
-	}{
-		{"HP", pokemon.Stats.HP},
-		{"Ataque", pokemon.Stats.Attack},
-		{"Defesa", pokemon.Stats.Defense},
-		{"Sp.Atk", pokemon.Stats.SpAtk},
-		{"Sp.Def", pokemon.Stats.SpDef},
-		{"Veloc.", pokemon.Stats.Speed},
-	}
-
-	for _, stat := range stats {
-		s.WriteString(fmt.Sprintf("  %-10s ", stat.name))
-		s.WriteString(renderStatBar(stat.value, 150))
+	s.WriteString(m.viewStatRanks(pokemon))
+
+	if len(pokemon.Abilities) > 0 {
+		s.WriteString("\n")
+		s.WriteString(getLabelStyle().Render(LabelABILITIES))
+		s.WriteString("  ")
+		s.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(fmt.Sprintf("[%s] %s", m.keys.ShowAbilities.Help().Key, m.keys.ShowAbilities.Help().Desc)))
 		s.WriteString("\n")
+
+		for _, ability := range pokemon.Abilities {
+			line := "  • " + m.pokedex.AbilityName(ability.Key)
+			if ability.Hidden {
+				line += " " + LabelHIDDEN_ABILITY
+			}
+			if effect := m.pokedex.AbilityEffect(ability.Key); effect != "" {
+				line += " - " + effect
+			}
+			s.WriteString(lipgloss.NewStyle().Width(m.width).Render(line))
+			s.WriteString("\n")
+		}
 	}
 
 	if pokemon.Evolution != nil {
@@ -621,132 +975,88 @@ func (m PokedexModel) viewDetail() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("[s] Alternar Shiny   [f] Favorito   [%s / %s] Navegar   [q] Voltar", LabelPREV, LabelNEXT)))
+	s.WriteString(m.helpView())
 
 	return s.String()
 }
 
 func (m PokedexModel) updatePokedexView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc":
+	switch {
+	case key.Matches(msg, m.keys.Back):
 		return m, func() tea.Msg { return MsgBack{} }
 
-	case "left", "h":
-		if m.currentPokemon != nil {
-			if len(m.pokemonList) > 0 {
-				for i, p := range m.pokemonList {
-					if p.ID == m.currentPokemon.ID {
-						newIdx := (i - 1 + len(m.pokemonList)) % len(m.pokemonList)
-						m.currentPokemon = m.pokemonList[newIdx]
-						m.pokemonListCursor = newIdx
-						break
-					}
-				}
-			} else {
-				m.currentPokemon = m.pokedex.GetPrevPokemon(m.currentPokemon.ID)
-			}
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
-		}
+	case key.Matches(msg, m.keys.Left):
+		m.showPrev()
 
-	case "right", "l":
-		if m.currentPokemon != nil {
-			if len(m.pokemonList) > 0 {
-				for i, p := range m.pokemonList {
-					if p.ID == m.currentPokemon.ID {
-						newIdx := (i + 1) % len(m.pokemonList)
-						m.currentPokemon = m.pokemonList[newIdx]
-						m.pokemonListCursor = newIdx
-						break
-					}
-				}
-			} else {
-				m.currentPokemon = m.pokedex.GetNextPokemon(m.currentPokemon.ID)
-			}
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
-		}
+	case key.Matches(msg, m.keys.Right):
+		m.showNext()
 
-	case "1":
-		m.state = StateSearch
-		m.searchInput.SetValue("")
-		m.searchInput.Focus()
-		m.searchResults = make([]*models.Pokemon, 0)
-		return m, textinput.Blink
+	case key.Matches(msg, m.keys.Search):
+		return m.openSearch()
 
-	case "v":
-		if m.renderMode == RenderHalfBlock {
-			m.renderMode = RenderSixel
-		} else {
-			m.renderMode = RenderHalfBlock
-		}
+	case key.Matches(msg, m.keys.ToggleRender):
+		m.renderMode = nextRenderMode(m.renderMode)
 		return m, nil
 
-	case "2":
-		m.state = StateBrowseType
-		m.typeCursor = 0
+	case key.Matches(msg, m.keys.BrowseTypes):
+		m.openBrowseType()
 		return m, nil
 
-	case "0":
-		// Clear filters
-		m.selectedType = ""
-		m.selectedGeneration = 0
-		m.pokemonList = make([]*models.Pokemon, 0)
+	case key.Matches(msg, m.keys.ClearFilters):
+		m.clearFilters()
 		return m, nil
 
-	case "3":
-		m.state = StateBrowseGeneration
-		m.generationCursor = 0
+	case key.Matches(msg, m.keys.BrowseGenerations):
+		m.openBrowseGeneration()
 		return m, nil
 
-	case "4":
-		m.state = StateFavorites
-		favIDs := m.favorites.GetAllFavorites()
-		sort.Ints(favIDs) // Sort favorites by ID
-		m.pokemonList = make([]*models.Pokemon, 0)
-		for _, id := range favIDs {
-			if pokemon := m.pokedex.GetByID(id); pokemon != nil {
-				m.pokemonList = append(m.pokemonList, pokemon)
-			}
-		}
-		m.favoritesCursor = 0
-		m.selectedType = ""
-		m.selectedGeneration = 0
+	case key.Matches(msg, m.keys.Favorites):
+		m.openFavorites()
 		return m, nil
 
-	case "enter", " ":
-		if m.currentPokemon != nil {
-			m.state = StateDetail
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
-		}
+	case key.Matches(msg, m.keys.BrowseAbilities):
+		m.openBrowseAbility(nil)
+		return m, nil
+
+	case key.Matches(msg, m.keys.BrowseMoves):
+		m.openBrowseMoves()
+		return m, nil
+
+	case key.Matches(msg, m.keys.BrowseEggGroups):
+		m.openBrowseEggGroup(nil)
+		return m, nil
+
+	case key.Matches(msg, m.keys.BrowseRegions):
+		m.openBrowseRegion()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Team) && m.team != nil:
+		m.openTeam()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Select):
+		m.openDetail()
 	}
 	return m, nil
 }
 
 func (m PokedexModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch {
+	case key.Matches(msg, m.keys.SearchCancel):
 		m.searchInput.Blur()
 		m.state = StatePokedexView
 		return m, nil
 
-	case "enter":
-		if len(m.searchResults) > 0 && m.selectedSearchIndex < len(m.searchResults) {
-			m.currentPokemon = m.searchResults[m.selectedSearchIndex]
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
-			m.searchInput.Blur()
-			m.state = StatePokedexView
-		}
+	case key.Matches(msg, m.keys.SearchSubmit):
+		m.selectSearchResult(m.selectedSearchIndex)
 		return m, nil
 
-	case "up":
-		if m.selectedSearchIndex > 0 {
-			m.selectedSearchIndex--
-		}
+	case key.Matches(msg, m.keys.SearchUp):
+		m.moveCursor(-1)
 		return m, nil
 
-	case "down":
-		if m.selectedSearchIndex < len(m.searchResults)-1 {
-			m.selectedSearchIndex++
-		}
+	case key.Matches(msg, m.keys.SearchDown):
+		m.moveCursor(1)
 		return m, nil
 	}
 
@@ -775,167 +1085,436 @@ func (m *PokedexModel) updateSearchResults() {
 }
 
 func (m PokedexModel) updateBrowseType(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc":
+	switch {
+	case key.Matches(msg, m.keys.Back):
 		m.state = StatePokedexView
 		return m, nil
 
-	case "up", "k":
-		if m.typeCursor > 0 {
-			m.typeCursor--
-		}
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
 
-	case "down", "j":
-		if m.typeCursor < len(TypeNames)-1 {
-			m.typeCursor++
-		}
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
 
-	case "enter", " ":
-		selectedType := TypeNames[m.typeCursor]
-		m.pokemonList = m.pokedex.GetPokemonByType(selectedType)
-		m.pokemonListCursor = 0
-		if len(m.pokemonList) > 0 {
-			m.currentPokemon = m.pokemonList[0]
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
-		}
-		m.selectedType = selectedType
-		m.selectedGeneration = 0
-		m.state = StatePokedexView
+	case key.Matches(msg, m.keys.Select):
+		m.selectType(m.typeCursor)
 	}
 	return m, nil
 }
 
 func (m PokedexModel) updateBrowseGeneration(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc":
+	switch {
+	case key.Matches(msg, m.keys.Back):
 		m.state = StatePokedexView
 		return m, nil
 
-	case "up", "k":
-		if m.generationCursor > 0 {
-			m.generationCursor--
-		}
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
 
-	case "down", "j":
-		if m.generationCursor < len(Generations)-1 {
-			m.generationCursor++
-		}
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
 
-	case "enter", " ":
-		selectedGen := Generations[m.generationCursor]
-		m.pokemonList = m.pokedex.GetPokemonByGeneration(selectedGen.ID)
-		m.generationListCursor = 0
-		if len(m.pokemonList) > 0 {
-			m.selectedGeneration = selectedGen.ID
-			m.selectedType = ""
-			m.state = StateBrowseGenerationList
-		}
+	case key.Matches(msg, m.keys.Select):
+		m.selectGeneration(m.generationCursor)
 	}
 	return m, nil
 }
 
 func (m PokedexModel) updateBrowseGenerationList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc":
+	switch {
+	case key.Matches(msg, m.keys.Back):
 		m.state = StateBrowseGeneration
 		return m, nil
 
-	case "up", "k":
-		if m.generationListCursor > 0 {
-			m.generationListCursor--
-		}
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
 
-	case "down", "j":
-		if m.generationListCursor < len(m.pokemonList)-1 {
-			m.generationListCursor++
-		}
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
 
-	case "enter", " ":
-		if m.generationListCursor < len(m.pokemonList) {
-			m.currentPokemon = m.pokemonList[m.generationListCursor]
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
-			m.state = StatePokedexView
-		}
+	case key.Matches(msg, m.keys.Select):
+		m.selectListPokemon(m.generationListCursor)
 	}
 	return m, nil
 }
 
 func (m PokedexModel) updateFavorites(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc":
+	switch {
+	case key.Matches(msg, m.keys.Back):
 		m.state = StatePokedexView
 		return m, nil
 
-	case "up", "k":
-		if m.favoritesCursor > 0 {
-			m.favoritesCursor--
-		}
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
 
-	case "down", "j":
-		if m.favoritesCursor < len(m.pokemonList)-1 {
-			m.favoritesCursor++
-		}
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectListPokemon(m.favoritesCursor)
+	}
+	return m, nil
+}
 
-	case "enter", " ":
-		if m.favoritesCursor < len(m.pokemonList) {
-			m.currentPokemon = m.pokemonList[m.favoritesCursor]
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
-			m.state = StatePokedexView
+func (m PokedexModel) updateBrowseAbility(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StatePokedexView
+		if m.abilitiesOf != nil {
+			m.state = StateDetail
 		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectAbility(m.abilityCursor)
+	}
+	return m, nil
+}
+
+func (m PokedexModel) updateBrowseAbilityList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StateBrowseAbility
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectListPokemon(m.abilityListCursor)
 	}
 	return m, nil
 }
 
 func (m PokedexModel) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc":
+	switch {
+	case key.Matches(msg, m.keys.Back):
 		m.state = StatePokedexView
 		return m, nil
 
-	case "s":
+	case key.Matches(msg, m.keys.ToggleShiny):
 		m.showShiny = !m.showShiny
 
-	case "f":
+	case key.Matches(msg, m.keys.ToggleRender):
+		m.renderMode = nextRenderMode(m.renderMode)
+
+	case key.Matches(msg, m.keys.ToggleFavorite):
 		if m.currentPokemon != nil {
 			isFav, _ := m.favorites.ToggleFavorite(m.currentPokemon.ID)
 			m.currentPokemon.IsFavorite = isFav
 		}
 
-	case "left", "h":
-		if m.currentPokemon != nil {
-			if len(m.pokemonList) > 0 {
-				for i, p := range m.pokemonList {
-					if p.ID == m.currentPokemon.ID {
-						newIdx := (i - 1 + len(m.pokemonList)) % len(m.pokemonList)
-						m.currentPokemon = m.pokemonList[newIdx]
-						m.pokemonListCursor = newIdx
-						break
-					}
-				}
-			} else {
-				m.currentPokemon = m.pokedex.GetPrevPokemon(m.currentPokemon.ID)
+	case key.Matches(msg, m.keys.CycleForm):
+		m.cycleForm()
+
+	case key.Matches(msg, m.keys.ShowAbilities):
+		m.openBrowseAbility(m.shownPokemon())
+
+	case key.Matches(msg, m.keys.ShowLearnset):
+		m.openLearnset()
+
+	case key.Matches(msg, m.keys.TogglePage):
+		m.detailPage = (m.detailPage + 1) % detailPages
+
+	case key.Matches(msg, m.keys.ShowEggGroups):
+		m.openBrowseEggGroup(m.shownPokemon())
+
+	case key.Matches(msg, m.keys.ShowCalculator):
+		m.openCalculator()
+
+	case key.Matches(msg, m.keys.AddToTeam):
+		m.addToTeam()
+
+	case key.Matches(msg, m.keys.Left):
+		m.showPrev()
+
+	case key.Matches(msg, m.keys.Right):
+		m.showNext()
+	}
+	return m, nil
+}
+
+// showPrev moves to the previous Pokemon, following the active filter list
+// when there is one.
+func (m *PokedexModel) showPrev() {
+	if m.currentPokemon == nil {
+		return
+	}
+	if len(m.pokemonList) > 0 {
+		for i, p := range m.pokemonList {
+			if p.ID == m.currentPokemon.ID {
+				newIdx := (i - 1 + len(m.pokemonList)) % len(m.pokemonList)
+				m.currentPokemon = m.pokemonList[newIdx]
+				m.pokemonListCursor = newIdx
+				break
 			}
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
 		}
+	} else {
+		m.currentPokemon = m.pokedex.GetPrevPokemon(m.currentPokemon.ID)
+	}
+	m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
+}
 
-	case "right", "l":
-		if m.currentPokemon != nil {
-			if len(m.pokemonList) > 0 {
-				for i, p := range m.pokemonList {
-					if p.ID == m.currentPokemon.ID {
-						newIdx := (i + 1) % len(m.pokemonList)
-						m.currentPokemon = m.pokemonList[newIdx]
-						m.pokemonListCursor = newIdx
-						break
-					}
-				}
-			} else {
-				m.currentPokemon = m.pokedex.GetNextPokemon(m.currentPokemon.ID)
+// showNext moves to the next Pokemon, following the active filter list when
+// there is one.
+func (m *PokedexModel) showNext() {
+	if m.currentPokemon == nil {
+		return
+	}
+	if len(m.pokemonList) > 0 {
+		for i, p := range m.pokemonList {
+			if p.ID == m.currentPokemon.ID {
+				newIdx := (i + 1) % len(m.pokemonList)
+				m.currentPokemon = m.pokemonList[newIdx]
+				m.pokemonListCursor = newIdx
+				break
 			}
-			m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
+		}
+	} else {
+		m.currentPokemon = m.pokedex.GetNextPokemon(m.currentPokemon.ID)
+	}
+	m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
+}
+
+func (m PokedexModel) openSearch() (tea.Model, tea.Cmd) {
+	m.state = StateSearch
+	m.searchInput.SetValue("")
+	m.searchInput.Focus()
+	m.searchResults = make([]*models.Pokemon, 0)
+	return m, textinput.Blink
+}
+
+func (m *PokedexModel) openBrowseType() {
+	m.state = StateBrowseType
+	m.typeCursor = 0
+}
+
+func (m *PokedexModel) openBrowseGeneration() {
+	m.state = StateBrowseGeneration
+	m.generationCursor = 0
+}
+
+func (m *PokedexModel) openFavorites() {
+	m.state = StateFavorites
+	favIDs := m.favorites.GetAllFavorites()
+	sort.Ints(favIDs) // Sort favorites by ID
+	m.pokemonList = make([]*models.Pokemon, 0)
+	for _, id := range favIDs {
+		if pokemon := m.pokedex.GetByID(id); pokemon != nil {
+			m.pokemonList = append(m.pokemonList, pokemon)
 		}
 	}
-	return m, nil
+	m.favoritesCursor = 0
+	m.selectedType = ""
+	m.selectedGeneration = 0
+	m.selectedAbility = ""
+	m.selectedMove = ""
+	m.selectedEggGroup = ""
+	m.selectedDex = ""
+}
+
+// openBrowseAbility lists the abilities of pokemon, or every ability when it
+// is nil.
+func (m *PokedexModel) openBrowseAbility(pokemon *models.Pokemon) {
+	m.state = StateBrowseAbility
+	m.abilitiesOf = pokemon
+	m.abilityCursor = 0
+	if pokemon != nil {
+		m.abilities = pokemon.Abilities
+		return
+	}
+	m.abilities = nil
+	for _, key := range m.pokedex.AbilityKeys() {
+		m.abilities = append(m.abilities, models.PokemonAbility{Key: key})
+	}
+}
+
+func (m *PokedexModel) openDetail() {
+	if m.currentPokemon != nil {
+		m.state = StateDetail
+		m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
+	}
+}
+
+func (m *PokedexModel) clearFilters() {
+	m.selectedType = ""
+	m.selectedGeneration = 0
+	m.selectedAbility = ""
+	m.selectedMove = ""
+	m.selectedEggGroup = ""
+	m.selectedDex = ""
+	m.pokemonList = make([]*models.Pokemon, 0)
+}
+
+// filtered reports whether the Pokedex screen browses a type, generation,
+// ability, move, egg group or regional dex rather than every Pokemon.
+func (m PokedexModel) filtered() bool {
+	return m.selectedType != "" || m.selectedGeneration > 0 || m.selectedAbility != "" || m.selectedMove != "" || m.selectedEggGroup != "" || m.selectedDex != ""
+}
+
+// moveCursor moves the cursor of the active list screen by delta, clamped to
+// the list bounds.
+func (m *PokedexModel) moveCursor(delta int) {
+	var cursor *int
+	var count int
+	switch m.state {
+	case StateSearch:
+		cursor, count = &m.selectedSearchIndex, len(m.searchResults)
+	case StateBrowseType:
+		cursor, count = &m.typeCursor, len(TypeNames)
+	case StateBrowseGeneration:
+		cursor, count = &m.generationCursor, len(Generations)
+	case StateBrowseGenerationList:
+		cursor, count = &m.generationListCursor, len(m.pokemonList)
+	case StateFavorites:
+		cursor, count = &m.favoritesCursor, len(m.pokemonList)
+	case StateBrowseAbility:
+		cursor, count = &m.abilityCursor, len(m.abilities)
+	case StateBrowseAbilityList:
+		cursor, count = &m.abilityListCursor, len(m.pokemonList)
+	case StateBrowseMoves:
+		cursor, count = &m.movesCursor, len(m.moves)
+	case StateBrowseMoveList:
+		cursor, count = &m.moveListCursor, len(m.pokemonList)
+	case StateBrowseEggGroup:
+		cursor, count = &m.eggGroupCursor, len(m.eggGroups)
+	case StateBrowseEggGroupList:
+		cursor, count = &m.eggGroupListCursor, len(m.pokemonList)
+	case StateBrowseRegion:
+		cursor, count = &m.regionCursor, len(m.pokedex.RegionalDexes)
+	case StateBrowseRegionList:
+		cursor, count = &m.regionListCursor, len(m.pokemonList)
+	case StateStatCalc:
+		cursor, count = &m.calcCursor, calcFields
+	case StateTeam:
+		cursor, count = &m.teamCursor, len(m.team.Members)
+	case StateRandomTeam:
+		cursor, count = &m.randomCursor, randomFirstSlot+len(m.randomTeam)
+	default:
+		return
+	}
+
+	*cursor += delta
+	if *cursor > count-1 {
+		*cursor = count - 1
+	}
+	if *cursor < 0 {
+		*cursor = 0
+	}
+}
+
+func (m *PokedexModel) selectSearchResult(index int) {
+	if index < 0 || index >= len(m.searchResults) {
+		return
+	}
+	m.currentPokemon = m.searchResults[index]
+	m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
+	m.searchInput.Blur()
+	m.state = StatePokedexView
+}
+
+func (m *PokedexModel) selectType(index int) {
+	if index < 0 || index >= len(TypeNames) {
+		return
+	}
+	selectedType := TypeNames[index]
+	m.pokemonList = m.pokedex.GetPokemonByType(selectedType)
+	m.pokemonListCursor = 0
+	if len(m.pokemonList) > 0 {
+		m.currentPokemon = m.pokemonList[0]
+		m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
+	}
+	m.selectedType = selectedType
+	m.selectedGeneration = 0
+	m.selectedAbility = ""
+	m.selectedMove = ""
+	m.selectedEggGroup = ""
+	m.selectedDex = ""
+	m.state = StatePokedexView
+}
+
+func (m *PokedexModel) selectGeneration(index int) {
+	if index < 0 || index >= len(Generations) {
+		return
+	}
+	selectedGen := Generations[index]
+	m.pokemonList = m.pokedex.GetPokemonByGeneration(selectedGen.ID)
+	m.generationListCursor = 0
+	if len(m.pokemonList) > 0 {
+		m.selectedGeneration = selectedGen.ID
+		m.selectedType = ""
+		m.selectedAbility = ""
+		m.selectedMove = ""
+		m.selectedEggGroup = ""
+		m.selectedDex = ""
+		m.state = StateBrowseGenerationList
+	}
+}
+
+func (m *PokedexModel) selectAbility(index int) {
+	if index < 0 || index >= len(m.abilities) {
+		return
+	}
+	selected := m.abilities[index].Key
+	m.pokemonList = m.pokedex.GetPokemonByAbility(selected)
+	m.abilityListCursor = 0
+	if len(m.pokemonList) > 0 {
+		m.selectedAbility = selected
+		m.selectedType = ""
+		m.selectedGeneration = 0
+		m.selectedMove = ""
+		m.selectedEggGroup = ""
+		m.selectedDex = ""
+		m.state = StateBrowseAbilityList
+	}
+}
+
+// selectListPokemon picks an entry of the generation, ability, egg group,
+// regional dex or favorites list.
+func (m *PokedexModel) selectListPokemon(index int) {
+	if index < 0 || index >= len(m.pokemonList) {
+		return
+	}
+	m.currentPokemon = m.pokemonList[index]
+	m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
+	m.state = StatePokedexView
+}
+
+// shownFormIndex returns formIndex while it still refers to the current
+// Pokemon, and 0 (the default form) after moving to another one.
+func (m PokedexModel) shownFormIndex() int {
+	if m.currentPokemon == nil || m.formOf != m.currentPokemon.ID {
+		return 0
+	}
+	return m.formIndex
+}
+
+// shownPokemon is the current Pokemon in the form picked in the detail view.
+func (m PokedexModel) shownPokemon() *models.Pokemon {
+	pokemon := m.GetCurrentPokemon()
+	if pokemon == nil {
+		return nil
+	}
+	return pokemon.WithForm(m.shownFormIndex() - 1)
+}
+
+// cycleForm moves to the next form of the current Pokemon, wrapping back to
+// the default one.
+func (m *PokedexModel) cycleForm() {
+	if m.currentPokemon == nil || len(m.currentPokemon.Forms) == 0 {
+		return
+	}
+	m.formIndex = (m.shownFormIndex() + 1) % (len(m.currentPokemon.Forms) + 1)
+	m.formOf = m.currentPokemon.ID
 }
 
 func (m PokedexModel) GetCurrentPokemon() *models.Pokemon {
@@ -945,34 +1524,67 @@ func (m PokedexModel) GetCurrentPokemon() *models.Pokemon {
 	return m.currentPokemon
 }
 
+// renderArt renders the Pokemon picture centered in the art area, leaving
+// reserved rows of the terminal to the rest of the view. Sprites
+// are drawn at runtime; the baked art is the fallback when there are none,
+// with a warning when the selected mode needed the sprite.
+func (m PokedexModel) renderArt(pokemon *models.Pokemon, reserved int) string {
+	art, err := m.renderSprite(pokemon.ArtID(), reserved)
+	if err != nil && pokemon.Form != nil {
+		// A form without a sprite of its own is drawn as the species
+		art, err = m.renderSprite(pokemon.ID, reserved)
+	}
+	if err == nil {
+		return art
+	}
+
+	art = m.loadPokemonArt(pokemon)
+
+	// Calculate appropriate width - use terminal width or default
+	artWidth := 65
+	if m.width > 0 && m.width < artWidth+10 {
+		artWidth = m.width - 10
+	}
+
+	warning := ""
+	if m.renderMode != RenderHalfBlock {
+		warning = getWarningStyle().
+			Align(lipgloss.Center).
+			Width(artWidth).
+			Render(fmt.Sprintf(LabelMODE_UNAVAILABLE, m.renderMode, err)) + "\n"
+	}
+
+	// Apply type-based coloring ONLY if art is not already colored (Braille legacy)
+	artStyle := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(artWidth)
+	if !strings.Contains(art, "\x1b[") && len(pokemon.Types) > 0 {
+		artStyle = artStyle.Foreground(getTypeColor(pokemon.Types[0]))
+	}
+	return warning + artStyle.Render(art)
+}
+
 func (m PokedexModel) loadPokemonArt(pokemon *models.Pokemon) string {
 	suffix := ""
 	if m.showShiny {
 		suffix = "_shiny"
 	}
 
-	// For ASCII mode, try embedded FS first (optimized binary)
-	if m.renderMode == RenderHalfBlock {
-		embeddedPath := fmt.Sprintf("embed/art/%d%s.ascii", pokemon.ID, suffix)
-		data, err := assets.EmbedFS.ReadFile(embeddedPath)
+	// Baked half-block art (embedded, or from the assets directory),
+	// converted to the terminal's colors once
+	for _, id := range []int{pokemon.ArtID(), pokemon.ID} {
+		key := artKey{id: id, shiny: m.showShiny, baked: true}
+		if art, ok := renderedArt.get(key); ok {
+			return art
+		}
+		data, err := assets.ReadFile(fmt.Sprintf("art/%d%s.ascii", id, suffix))
 		if err == nil {
-			return string(data)
+			art := adaptArt(string(data), colorProfile())
+			renderedArt.put(key, art)
+			return art
 		}
 	}
 
-	// Determine extension based on render mode
-	ext := ".ascii"
-	if m.renderMode == RenderSixel {
-		ext = ".sixel"
-	}
-
-	// Try to load from disk (for Sixel mode or fallback)
-	path := fmt.Sprintf("assets/art/%d%s%s", pokemon.ID, suffix, ext)
-	data, err := os.ReadFile(path)
-	if err == nil {
-		return string(data)
-	}
-
 	// Fallback to legacy hardcoded art
 	if m.showShiny {
 		return pokemon.ArtShiny
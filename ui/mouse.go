@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Mouse hit-testing works on the rendered view: clicks are mapped back onto
+// the lines and labels actually drawn, so the hit areas follow the layout.
+
+func (m PokedexModel) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scroll(-1)
+	case tea.MouseButtonWheelDown:
+		m.scroll(1)
+	case tea.MouseButtonLeft:
+		return m.click(msg.X, ViewRow(m.View(), msg.Y, m.height))
+	}
+	return m, nil
+}
+
+// scroll moves list cursors with the mouse wheel, or browses Pokemon on the
+// Pokedex and detail screens.
+func (m *PokedexModel) scroll(delta int) {
+	switch m.state {
+	case StatePokedexView, StateDetail:
+		if delta < 0 {
+			m.showPrev()
+		} else {
+			m.showNext()
+		}
+	case StateLearnset:
+		m.scrollLearnset(delta)
+	default:
+		m.moveCursor(delta)
+	}
+}
+
+func (m PokedexModel) click(x, row int) (tea.Model, tea.Cmd) {
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	if row < 0 || row >= len(lines) {
+		return m, nil
+	}
+	line := lines[row]
+
+	switch m.state {
+	case StatePokedexView:
+		for _, item := range m.pokedexMenu() {
+			if textHit(line, item.String(), x) {
+				return item.action(m)
+			}
+		}
+		if textHit(line, LabelPREV, x) {
+			m.showPrev()
+		} else if textHit(line, LabelNEXT, x) {
+			m.showNext()
+		} else if textHit(line, LabelDETAILS_HINT, x) {
+			m.openDetail()
+		}
+
+	case StateDetail:
+		if strings.Contains(line, LabelNORMAL) && strings.Contains(line, LabelSHINY) {
+			if textHit(line, LabelNORMAL, x) {
+				m.showShiny = false
+			} else if textHit(line, LabelSHINY, x) {
+				m.showShiny = true
+			}
+		} else if strings.HasPrefix(line, LabelFORM) {
+			m.cycleForm()
+		} else if strings.HasPrefix(line, LabelABILITIES) {
+			m.openBrowseAbility(m.shownPokemon())
+		} else if strings.HasPrefix(line, LabelSTATS) {
+			m.openCalculator()
+		} else if strings.HasPrefix(line, LabelBREEDING) {
+			m.openBrowseEggGroup(m.shownPokemon())
+		} else if strings.HasPrefix(line, fmt.Sprintf(LabelPAGE, m.detailPage+1, detailPages)) {
+			m.detailPage = (m.detailPage + 1) % detailPages
+		} else if textHit(line, LabelPREV, x) {
+			m.showPrev()
+		} else if textHit(line, LabelNEXT, x) {
+			m.showNext()
+		}
+
+	case StateLearnset:
+		if textHit(line, LabelPREV, x) {
+			m.cycleVersionGroup(-1)
+		} else if textHit(line, LabelNEXT, x) {
+			m.cycleVersionGroup(1)
+		}
+
+	case StateTeam:
+		// Each member takes two lines
+		index := (row - strings.Count(m.listHeader(), "\n")) / 2
+		if row >= strings.Count(m.listHeader(), "\n") && index < len(m.team.Members) {
+			m.selectTeamMember(index)
+		}
+
+	case StateRandomTeam:
+		// The constraints follow their title; the slots follow a blank line
+		// and the title of the team
+		field := row - strings.Count(m.listHeader(), "\n") - 1
+		if field >= randomFirstSlot {
+			field -= 2
+		}
+		if field >= 0 && field < randomFirstSlot {
+			m.randomCursor = field
+			m.adjustRandomConstraint(1)
+		} else if field >= randomFirstSlot {
+			m.selectRandomSlot(field - randomFirstSlot)
+		}
+
+	default:
+		start, end := m.visibleRange()
+		index := start + row - strings.Count(m.listHeader(), "\n")
+		if index < start || index >= end {
+			return m, nil
+		}
+		switch m.state {
+		case StateSearch:
+			m.selectSearchResult(index)
+		case StateBrowseType:
+			m.selectType(index)
+		case StateBrowseGeneration:
+			m.selectGeneration(index)
+		case StateBrowseAbility:
+			m.selectAbility(index)
+		case StateBrowseEggGroup:
+			m.selectEggGroup(index)
+		case StateBrowseRegion:
+			m.selectRegion(index)
+		case StateBrowseGenerationList, StateFavorites, StateBrowseAbilityList, StateBrowseEggGroupList, StateBrowseRegionList:
+			m.selectListPokemon(index)
+		case StateBrowseMoves:
+			m.selectMove(index)
+		case StateBrowseMoveList:
+			m.selectMovePokemon(index)
+		}
+	}
+	return m, nil
+}
+
+// textHit reports whether column x of a plain-text line falls on label.
+func textHit(line, label string, x int) bool {
+	idx := strings.Index(line, label)
+	if idx < 0 {
+		return false
+	}
+	start := ansi.StringWidth(line[:idx])
+	return x >= start && x < start+ansi.StringWidth(label)
+}
+
+// ViewRow converts a terminal row into a row of the rendered view. Views
+// taller than the terminal are drawn with their top lines cut off.
+func ViewRow(view string, y, height int) int {
+	lines := strings.Count(view, "\n") + 1
+	if height > 0 && lines > height {
+		y += lines - height
+	}
+	return y
+}
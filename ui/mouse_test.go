@@ -0,0 +1,25 @@
+package ui
+
+import "testing"
+
+func TestTextHit(t *testing.T) {
+	// The emoji takes two columns, so the label spans columns 8 to 20
+	line := "        [9] 👥 Equipa        "
+	label := "[9] 👥 Equipa"
+	tests := []struct {
+		x    int
+		want bool
+	}{
+		{0, false},
+		{7, false},
+		{8, true},
+		{20, true},
+		{21, false},
+		{25, false},
+	}
+	for _, tt := range tests {
+		if got := textHit(line, label, tt.x); got != tt.want {
+			t.Errorf("textHit(x=%d) = %v, want %v", tt.x, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// moveCategories are the categories the moves screen filters by, after "",
+// which shows them all.
+var moveCategories = []string{"", "physical", "special", "status"}
+
+// openBrowseMoves lists the moves of the catalog under the current filters.
+func (m *PokedexModel) openBrowseMoves() {
+	m.state = StateBrowseMoves
+	m.filterMoves()
+}
+
+// filterMoves lists the moves of the type and category picked, moving the
+// cursor to the top.
+func (m *PokedexModel) filterMoves() {
+	m.moves = nil
+	for _, key := range m.pokedex.MoveKeys() {
+		move := m.pokedex.GetMove(key)
+		if m.moveType != "" && move.Type != m.moveType {
+			continue
+		}
+		if m.moveCategory != "" && move.Category != m.moveCategory {
+			continue
+		}
+		m.moves = append(m.moves, key)
+	}
+	m.movesCursor = 0
+}
+
+// cycleMoveType moves the type filter to the next type, then back to every
+// type.
+func (m *PokedexModel) cycleMoveType() {
+	next := ""
+	for i, t := range TypeNames {
+		if m.moveType == "" {
+			next = TypeNames[0]
+			break
+		}
+		if t == m.moveType && i+1 < len(TypeNames) {
+			next = TypeNames[i+1]
+			break
+		}
+	}
+	m.moveType = next
+	m.filterMoves()
+}
+
+func (m *PokedexModel) cycleMoveCategory() {
+	for i, c := range moveCategories {
+		if c == m.moveCategory {
+			m.moveCategory = moveCategories[(i+1)%len(moveCategories)]
+			break
+		}
+	}
+	m.filterMoves()
+}
+
+func (m PokedexModel) updateBrowseMoves(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StatePokedexView
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.FilterType):
+		m.cycleMoveType()
+
+	case key.Matches(msg, m.keys.FilterCategory):
+		m.cycleMoveCategory()
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectMove(m.movesCursor)
+	}
+	return m, nil
+}
+
+func (m PokedexModel) updateBrowseMoveList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StateBrowseMoves
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectMovePokemon(m.moveListCursor)
+	}
+	return m, nil
+}
+
+// selectMove lists the Pokemon that learn the move at index.
+func (m *PokedexModel) selectMove(index int) {
+	if index < 0 || index >= len(m.moves) {
+		return
+	}
+	selected := m.moves[index]
+	m.pokemonList = m.pokedex.GetPokemonByMove(selected)
+	m.moveListCursor = 0
+	if len(m.pokemonList) > 0 {
+		m.selectedMove = selected
+		m.selectedType = ""
+		m.selectedGeneration = 0
+		m.selectedAbility = ""
+		m.selectedEggGroup = ""
+		m.selectedDex = ""
+		m.state = StateBrowseMoveList
+	}
+}
+
+// selectMovePokemon opens the detail view of a Pokemon that learns the
+// move; browsing from there follows the list of learners.
+func (m *PokedexModel) selectMovePokemon(index int) {
+	if index < 0 || index >= len(m.pokemonList) {
+		return
+	}
+	m.currentPokemon = m.pokemonList[index]
+	m.openDetail()
+}
+
+// movesHeader is the filter line and the column titles of the moves screen.
+func (m PokedexModel) movesHeader() string {
+	typeName := LabelALL_TYPES
+	if m.moveType != "" {
+		typeName = getTypeEmoji(m.moveType) + " " + m.moveType
+	}
+	category := LabelALL_CATEGORIES
+	if m.moveCategory != "" {
+		category = moveCategoryNames[m.moveCategory]
+	}
+	hint := lipgloss.NewStyle().Foreground(theme.Accent)
+
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("%s %s  %s  %s %s  %s\n", LabelTYPE, typeName,
+		hint.Render(fmt.Sprintf("[%s] %s", m.keys.FilterType.Help().Key, m.keys.FilterType.Help().Desc)),
+		LabelCATEGORY, category,
+		hint.Render(fmt.Sprintf("[%s] %s", m.keys.FilterCategory.Help().Key, m.keys.FilterCategory.Help().Desc))))
+	s.WriteString("\n")
+	s.WriteString(getLabelStyle().Render(fmt.Sprintf("  %-24s %-12s %-9s %5s %5s %3s", LabelMOVE_COLUMN, "Tipo", "Categoria", "Poder", "Prec.", "PP")))
+	s.WriteString("\n")
+	return s.String()
+}
+
+func (m PokedexModel) viewBrowseMoves() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
+	if len(m.moves) == 0 {
+		s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelNO_MOVES))
+		s.WriteString("\n\n")
+		s.WriteString(m.helpView())
+		return s.String()
+	}
+
+	startIdx, endIdx := m.visibleRange()
+	for i := startIdx; i < endIdx; i++ {
+		move := m.pokedex.GetMove(m.moves[i])
+		cursor := " "
+		style := getNormalItemStyle()
+		if i == m.movesCursor {
+			cursor = ">"
+			style = getCursorStyle()
+		}
+
+		typeCell := lipgloss.NewStyle().Foreground(getTypeColor(move.Type)).Render(fmt.Sprintf("%-12s", move.Type))
+		s.WriteString(style.Render(fmt.Sprintf("%s %-24s ", cursor, truncate(m.pokedex.MoveName(move.Key), 24))))
+		s.WriteString(typeCell)
+		s.WriteString(style.Render(fmt.Sprintf(" %-9s %5s %5s %3s",
+			moveCategoryNames[move.Category], orDash(move.Power), orDash(move.Accuracy), orDash(move.PP))))
+		s.WriteString("\n")
+	}
+
+	learners := len(m.pokedex.GetPokemonByMove(m.moves[m.movesCursor]))
+	s.WriteString("\n")
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf(LabelLEARNED_BY, learners, len(m.moves))))
+	s.WriteString("\n\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
+
+// orDash prints a move number, with a dash for the zero that stands for "no
+// power", "never misses" or "unknown".
+func orDash(n int) string {
+	if n == 0 {
+		return "—"
+	}
+	return fmt.Sprint(n)
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"charm-pokemon/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The constraints of the random team screen, in cursor order. The slots of
+// the team follow them.
+const (
+	randomGeneration = iota
+	randomType
+	randomUniqueTypes
+	randomMinBST
+	randomMaxBST
+	randomFullyEvolved
+	randomNoLegendaries
+	randomFavorites
+	randomFirstSlot
+)
+
+// bstStep is how much left and right change the base stat total limits.
+const bstStep = 50
+
+// maxBSTLimit is the highest limit offered, above every base stat total.
+const maxBSTLimit = 800
+
+// openRandomTeam shows the generator, drawing a first team with a fresh
+// seed. The constraints are kept from the last time.
+func (m *PokedexModel) openRandomTeam() {
+	m.state = StateRandomTeam
+	m.randomCursor = 0
+	m.rollRandomTeam(time.Now().UnixNano())
+}
+
+// rollRandomTeam draws a whole team with seed under the current constraints.
+func (m *PokedexModel) rollRandomTeam(seed int64) {
+	constraints := models.TeamConstraints{
+		UniqueTypes:   m.randomUniqueTypes,
+		MinBST:        m.randomMinBST,
+		MaxBST:        m.randomMaxBST,
+		FullyEvolved:  m.randomFullyEvolved,
+		NoLegendaries: m.randomNoLegendaries,
+	}
+	if m.randomGeneration > 0 {
+		constraints.Generations = []int{m.randomGeneration}
+	}
+	if m.randomType != "" {
+		constraints.Types = []string{m.randomType}
+	}
+	if m.randomFavorites {
+		constraints.Favorites = m.favorites.Favorites
+	}
+
+	m.randomSeed = seed
+	m.randomGenerator, m.randomErr = models.NewTeamGenerator(m.pokedex, constraints, seed)
+	m.randomTeam = nil
+	if m.randomErr == nil {
+		m.randomTeam, m.randomErr = m.randomGenerator.Generate()
+	}
+	if m.randomCursor >= randomFirstSlot+len(m.randomTeam) {
+		m.randomCursor = randomFirstSlot
+	}
+}
+
+func (m PokedexModel) updateRandomTeam(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StateTeam
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Left):
+		m.adjustRandomConstraint(-1)
+
+	case key.Matches(msg, m.keys.Right):
+		m.adjustRandomConstraint(1)
+
+	case key.Matches(msg, m.keys.Select):
+		if m.randomCursor >= randomFirstSlot {
+			m.selectRandomSlot(m.randomCursor - randomFirstSlot)
+		} else {
+			m.adjustRandomConstraint(1)
+		}
+
+	case key.Matches(msg, m.keys.RerollSlot):
+		if slot := m.randomCursor - randomFirstSlot; slot >= 0 && slot < len(m.randomTeam) {
+			pokemon, err := m.randomGenerator.Reroll(m.randomTeam, slot)
+			m.randomErr = err
+			if err == nil {
+				m.randomTeam[slot] = pokemon
+			}
+		}
+
+	case key.Matches(msg, m.keys.NewTeam):
+		m.rollRandomTeam(time.Now().UnixNano())
+
+	case key.Matches(msg, m.keys.SaveTeam):
+		if len(m.randomTeam) == 0 {
+			return m, nil
+		}
+		err := m.team.Set(models.NewTeam(m.randomTeam))
+		m.openTeam()
+		m.teamNotice = LabelRANDOM_SAVED
+		if err != nil {
+			m.teamNotice = err.Error()
+		}
+	}
+	return m, nil
+}
+
+// adjustRandomConstraint changes the constraint under the cursor and draws
+// the team again with the same seed, so only the change shows.
+func (m *PokedexModel) adjustRandomConstraint(delta int) {
+	switch m.randomCursor {
+	case randomGeneration:
+		n := len(Generations) + 1
+		m.randomGeneration = (m.randomGeneration + delta + n) % n
+	case randomType:
+		index := 0
+		for i, t := range TypeNames {
+			if t == m.randomType {
+				index = i + 1
+			}
+		}
+		n := len(TypeNames) + 1
+		index = (index + delta + n) % n
+		m.randomType = ""
+		if index > 0 {
+			m.randomType = TypeNames[index-1]
+		}
+	case randomUniqueTypes:
+		m.randomUniqueTypes = !m.randomUniqueTypes
+	case randomMinBST:
+		m.randomMinBST = clamp(m.randomMinBST+delta*bstStep, 0, maxBSTLimit)
+	case randomMaxBST:
+		m.randomMaxBST = clamp(m.randomMaxBST+delta*bstStep, 0, maxBSTLimit)
+	case randomFullyEvolved:
+		if !m.pokedex.SupportsFullyEvolved() {
+			return
+		}
+		m.randomFullyEvolved = !m.randomFullyEvolved
+	case randomNoLegendaries:
+		if !m.pokedex.SupportsNoLegendaries() {
+			return
+		}
+		m.randomNoLegendaries = !m.randomNoLegendaries
+	case randomFavorites:
+		m.randomFavorites = !m.randomFavorites
+	default:
+		return
+	}
+	m.rollRandomTeam(m.randomSeed)
+}
+
+// selectRandomSlot opens the detail view of the Pokemon in slot.
+func (m *PokedexModel) selectRandomSlot(slot int) {
+	if slot < 0 || slot >= len(m.randomTeam) {
+		return
+	}
+	m.currentPokemon = m.randomTeam[slot]
+	m.currentPokemon.IsFavorite = m.favorites.IsFavorite(m.currentPokemon.ID)
+	m.state = StateDetail
+}
+
+func yesNo(value bool) string {
+	if value {
+		return LabelYES
+	}
+	return LabelNO
+}
+
+func (m PokedexModel) viewRandomTeam() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
+	row := func(field int, label, value string) {
+		cursor := " "
+		style := getNormalItemStyle()
+		if field == m.randomCursor {
+			cursor = ">"
+			style = getCursorStyle()
+		}
+		s.WriteString(fmt.Sprintf("%s %-22s %s\n", cursor, label, style.Render("◀ "+value+" ▶")))
+	}
+
+	generation := LabelANY
+	if m.randomGeneration > 0 {
+		generation = Generations[m.randomGeneration-1].NamePT
+	}
+	pokemonType := LabelANY
+	if m.randomType != "" {
+		pokemonType = getTypeEmoji(m.randomType) + " " + m.randomType
+	}
+	bst := func(limit int) string {
+		if limit == 0 {
+			return LabelANY
+		}
+		return fmt.Sprint(limit)
+	}
+
+	s.WriteString(getLabelStyle().Render(LabelRANDOM_CONSTRAINTS))
+	s.WriteString("\n")
+	row(randomGeneration, LabelRANDOM_GENERATION, generation)
+	row(randomType, LabelRANDOM_TYPE, pokemonType)
+	row(randomUniqueTypes, LabelRANDOM_UNIQUE, yesNo(m.randomUniqueTypes))
+	row(randomMinBST, LabelRANDOM_MIN_BST, bst(m.randomMinBST))
+	row(randomMaxBST, LabelRANDOM_MAX_BST, bst(m.randomMaxBST))
+	// Constraints the data cannot evaluate stay off
+	supported := func(ok bool, value string) string {
+		if !ok {
+			return LabelUNAVAILABLE
+		}
+		return value
+	}
+	row(randomFullyEvolved, LabelRANDOM_EVOLVED, supported(m.pokedex.SupportsFullyEvolved(), yesNo(m.randomFullyEvolved)))
+	row(randomNoLegendaries, LabelRANDOM_LEGENDARY, supported(m.pokedex.SupportsNoLegendaries(), yesNo(m.randomNoLegendaries)))
+	row(randomFavorites, LabelRANDOM_FAVORITES, yesNo(m.randomFavorites))
+
+	s.WriteString("\n")
+	candidates := 0
+	if m.randomGenerator != nil {
+		candidates = len(m.randomGenerator.Candidates())
+	}
+	s.WriteString(getLabelStyle().Render(fmt.Sprintf(LabelRANDOM_TEAM, m.randomSeed, candidates)))
+	s.WriteString("\n")
+	for i, pokemon := range m.randomTeam {
+		cursor := " "
+		style := getNormalItemStyle()
+		if randomFirstSlot+i == m.randomCursor {
+			cursor = ">"
+			style = getCursorStyle()
+		}
+		typeEmojis := ""
+		for _, t := range pokemon.Types {
+			typeEmojis += getTypeEmoji(t) + " "
+		}
+		s.WriteString(style.Render(fmt.Sprintf("%s %d. #%-4d %-20s %-8s BST %d", cursor, i+1, pokemon.ID, pokemon.NamePT, typeEmojis, pokemon.Stats.Total())))
+		s.WriteString("\n")
+	}
+
+	if m.randomErr != nil {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(m.randomErr.Error()))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"charm-pokemon/models"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// rankScope is a group of Pokemon a stat is ranked within.
+type rankScope struct {
+	name  string
+	stats *models.StatDistribution
+}
+
+// The columns of the stat ranks: each row is indented, names the stat and
+// draws its bar, followed by a rank cell per scope. rankCellWidth fits
+// "#1025 100%" and the column names above it.
+const (
+	statIndent    = 2
+	statNameWidth = 10
+	statRowWidth  = statIndent + statNameWidth + 1 + statBarWidth + statBarGap + statValueWidth
+	rankCellGap   = 2
+	rankCellWidth = 14
+)
+
+// rankScopes lists the groups pokemon is ranked within: every Pokemon, its
+// generation and each of its types, leaving out the ones with no data.
+func (m PokedexModel) rankScopes(pokemon *models.Pokemon) []rankScope {
+	scopes := []rankScope{{LabelRANK_ALL, m.pokedex.StatDistribution()}}
+	if stats := m.pokedex.GenerationStatDistribution(pokemon.Generation); stats != nil {
+		scopes = append(scopes, rankScope{fmt.Sprintf(LabelRANK_GENERATION, pokemon.Generation), stats})
+	}
+	for _, t := range pokemon.Types {
+		if stats := m.pokedex.TypeStatDistribution(t); stats != nil {
+			scopes = append(scopes, rankScope{t, stats})
+		}
+	}
+	return scopes
+}
+
+// viewStatRanks renders the base stats and their total, each with its rank
+// and percentile in every scope that fits the window. The bars are colored
+// by the percentile among all Pokemon.
+func (m PokedexModel) viewStatRanks(pokemon *models.Pokemon) string {
+	var s strings.Builder
+
+	scopes := m.rankScopes(pokemon)
+	if m.width > 0 {
+		fit := (m.width - statRowWidth) / (rankCellGap + rankCellWidth)
+		if fit < 1 {
+			fit = 1
+		}
+		if fit < len(scopes) {
+			scopes = scopes[:fit]
+		}
+	}
+
+	header := strings.Repeat(" ", statRowWidth)
+	for _, scope := range scopes {
+		header += rankCell(fmt.Sprintf("%s (%d)", scope.name, scope.stats.Len()))
+	}
+	s.WriteString(getLabelStyle().Render(header))
+	s.WriteString("\n")
+
+	all := m.pokedex.StatDistribution()
+	rows := append(append([]models.Stat(nil), models.AllStats...), models.StatTotal)
+	for _, stat := range rows {
+		value := pokemon.Stats.Get(stat)
+		maxValue := models.MaxBaseStat
+		if stat == models.StatTotal {
+			maxValue = all.Max(models.StatTotal)
+		}
+
+		s.WriteString(statRow(statNames[stat], value, maxValue, all.Rank(stat, value).Percentile))
+		for _, scope := range scopes {
+			rank := scope.stats.Rank(stat, value)
+			s.WriteString(rankCell(fmt.Sprintf("#%-4d %3.0f%%", rank.Rank, rank.Percentile)))
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render(strings.Repeat(" ", statIndent) + LabelRANK_HINT))
+	s.WriteString("\n")
+
+	return s.String()
+}
+
+// statRow renders the name and bar of a stat, statRowWidth columns wide.
+func statRow(name string, value, maxValue int, percentile float64) string {
+	return fmt.Sprintf("%*s%-*s ", statIndent, "", statNameWidth, name) +
+		renderRankedStatBar(value, maxValue, percentile)
+}
+
+// rankCell renders one column of the ranks, preceded by its gap.
+func rankCell(text string) string {
+	return strings.Repeat(" ", rankCellGap) + lipgloss.NewStyle().Width(rankCellWidth).Render(text)
+}
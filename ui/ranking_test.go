@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"charm-pokemon/data"
+	"charm-pokemon/models"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestStatRowWidth(t *testing.T) {
+	for _, value := range []int{1, 45, 255} {
+		for _, name := range []string{"HP", statNames[models.StatTotal]} {
+			if got := lipgloss.Width(statRow(name, value, models.MaxBaseStat, 50)); got != statRowWidth {
+				t.Errorf("statRow(%q, %d) is %d columns, want %d", name, value, got, statRowWidth)
+			}
+		}
+	}
+}
+
+func TestViewStatRanksFitsWidth(t *testing.T) {
+	pokedex, err := data.LoadPokedex(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pokemon := pokedex.GetByID(25)
+	for _, width := range []int{statRowWidth + rankCellGap + rankCellWidth, 80, 120} {
+		m := PokedexModel{pokedex: pokedex, width: width}
+		for _, line := range strings.Split(m.viewStatRanks(pokemon), "\n") {
+			if strings.Contains(line, LabelRANK_HINT) {
+				continue // prose, wrapped by the terminal
+			}
+			if w := lipgloss.Width(line); w > width {
+				t.Errorf("at width %d, line is %d columns: %q", width, w, line)
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"charm-pokemon/models"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *PokedexModel) openBrowseRegion() {
+	m.state = StateBrowseRegion
+	m.regionCursor = 0
+}
+
+func (m PokedexModel) updateBrowseRegion(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StatePokedexView
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectRegion(m.regionCursor)
+	}
+	return m, nil
+}
+
+func (m PokedexModel) updateBrowseRegionList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StateBrowseRegion
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectListPokemon(m.regionListCursor)
+	}
+	return m, nil
+}
+
+// selectRegion lists the Pokemon of the dex at index in its own order, which
+// browsing with left and right then follows.
+func (m *PokedexModel) selectRegion(index int) {
+	if index < 0 || index >= len(m.pokedex.RegionalDexes) {
+		return
+	}
+	dex := m.pokedex.RegionalDexes[index]
+	m.pokemonList = m.pokedex.GetPokemonByRegionalDex(dex.Key)
+	m.regionListCursor = 0
+	if len(m.pokemonList) > 0 {
+		m.selectedDex = dex.Key
+		m.selectedType = ""
+		m.selectedGeneration = 0
+		m.selectedAbility = ""
+		m.selectedMove = ""
+		m.selectedEggGroup = ""
+		m.state = StateBrowseRegionList
+	}
+}
+
+// dexNumber is the number shown for pokemon: "#regional / #national" while a
+// regional dex that has it is browsed, the national number otherwise.
+func (m PokedexModel) dexNumber(pokemon *models.Pokemon) string {
+	if m.selectedDex != "" {
+		if dex := m.pokedex.GetRegionalDex(m.selectedDex); dex != nil {
+			if number, ok := dex.Number(pokemon.ID); ok {
+				return fmt.Sprintf("#%03d / #%d", number, pokemon.ID)
+			}
+		}
+	}
+	return fmt.Sprintf("#%d", pokemon.ID)
+}
+
+func (m PokedexModel) viewBrowseRegion() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
+	if len(m.pokedex.RegionalDexes) == 0 {
+		s.WriteString(lipgloss.NewStyle().Faint(true).Render(LabelNO_REGIONS))
+		s.WriteString("\n\n")
+		s.WriteString(m.helpView())
+		return s.String()
+	}
+
+	startIdx, endIdx := m.visibleRange()
+	for i := startIdx; i < endIdx; i++ {
+		dex := m.pokedex.RegionalDexes[i]
+		cursor := " "
+		style := getNormalItemStyle()
+		if i == m.regionCursor {
+			cursor = ">"
+			style = getCursorStyle()
+		}
+
+		s.WriteString(lipgloss.NewStyle().
+			Align(lipgloss.Left).
+			Width(m.width).
+			Render(style.Render(fmt.Sprintf("%s %-28s - %4d %s", cursor, dex.Name(), len(dex.Entries), LabelPOKEMON))) + "\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
+
+// viewBrowseRegionList renders the Pokemon of a regional dex with both their
+// numbers.
+func (m PokedexModel) viewBrowseRegionList() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
+	startIdx, endIdx := m.visibleRange()
+	for i := startIdx; i < endIdx; i++ {
+		pokemon := m.pokemonList[i]
+		cursor := " "
+		style := getNormalItemStyle()
+		if i == m.regionListCursor {
+			cursor = ">"
+			style = getCursorStyle()
+		}
+
+		typeEmoji := ""
+		if len(pokemon.Types) > 0 {
+			typeEmoji = getTypeEmoji(pokemon.Types[0])
+		}
+
+		s.WriteString(lipgloss.NewStyle().
+			Align(lipgloss.Left).
+			Width(m.width).
+			Render(style.Render(fmt.Sprintf("%s %-14s %-20s %s", cursor, m.dexNumber(pokemon), pokemon.NamePT, typeEmoji))) + "\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
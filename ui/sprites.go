@@ -0,0 +1,304 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png"
+	"strings"
+
+	"charm-pokemon/assets"
+	"golang.org/x/image/draw"
+)
+
+// Sprites are stored as small PNGs and drawn at runtime, so the art follows
+// the size of the terminal instead of a size fixed when the data was built.
+
+const (
+	maxArtCols = 96 // beyond this the sprites are only blown up
+	minArtCols = 16
+	minArtRows = 8
+)
+
+// spriteData returns the embedded PNG for a Pokemon.
+func spriteData(id int, shiny bool) ([]byte, error) {
+	suffix := ""
+	if shiny {
+		suffix = "_shiny"
+	}
+	return assets.ReadFile(fmt.Sprintf("sprites/%d%s.png", id, suffix))
+}
+
+// artArea returns the columns and rows available for the art when reserved
+// rows of the terminal go to the text around it. Every row of art ends with
+// a newline, so the art takes one row more than it draws. Below the minimum
+// the view grows taller than the terminal instead.
+func (m PokedexModel) artArea(reserved int) (int, int) {
+	width := 65
+	if m.width > 0 {
+		width = m.width - 10
+	}
+	return max(width, minArtCols), max(m.height-reserved-1, minArtRows)
+}
+
+// fitArt sizes an image of the given pixel size to the largest cell area
+// that fits, keeping its aspect ratio. Cells are about twice as tall as
+// they are wide.
+func fitArt(size image.Point, maxCols, maxRows int) (int, int) {
+	if size.X == 0 || size.Y == 0 {
+		return 0, 0
+	}
+	cols := min(maxCols, maxArtCols, maxRows*2*size.X/size.Y)
+	rows := (cols*size.Y/size.X + 1) / 2
+	return max(cols, 1), max(rows, 1)
+}
+
+// scaleImage resizes img to w×h pixels, keeping hard pixel edges when
+// enlarging.
+func scaleImage(img image.Image, w, h int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	var scaler draw.Scaler = draw.CatmullRom
+	if w >= img.Bounds().Dx() {
+		scaler = draw.NearestNeighbor
+	}
+	scaler.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return dst
+}
+
+// renderSprite draws the sprite in the current mode, sized to the art area
+// and centered in it.
+func (m PokedexModel) renderSprite(id, reserved int) (string, error) {
+	data, err := spriteData(id, m.showShiny)
+	if err != nil {
+		return "", fmt.Errorf("sem sprite para #%d", id)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("sprite de #%d inválido: %w", id, err)
+	}
+
+	areaWidth, areaHeight := m.artArea(reserved)
+	cols, rows := fitArt(image.Pt(cfg.Width, cfg.Height), areaWidth, areaHeight)
+
+	key := artKey{id: id, shiny: m.showShiny, width: cols, mode: m.renderMode}
+	art, ok := renderedArt.get(key)
+	if !ok {
+		art, err = drawSprite(data, m.renderMode, cols, rows)
+		if err != nil {
+			return "", fmt.Errorf("sprite de #%d inválido: %w", id, err)
+		}
+		renderedArt.put(key, art)
+	}
+	return centerArt(art, cols, areaWidth), nil
+}
+
+// drawSprite renders a PNG sprite in cols×rows cells. The inline image
+// protocols get a line for the image followed by blank lines reserving its
+// height, so the text below lands under the picture.
+func drawSprite(data []byte, mode RenderMode, cols, rows int) (string, error) {
+	switch mode {
+	case RenderKitty:
+		return kittyImage(data, cols, rows) + strings.Repeat("\n", rows), nil
+	case RenderITerm:
+		return itermImage(data, cols, rows) + strings.Repeat("\n", rows), nil
+	}
+
+	img, err := decodeSprite(data)
+	if err != nil {
+		return "", err
+	}
+
+	var art string
+	switch mode {
+	case RenderSixel:
+		return sixelImage(img, cols, rows) + strings.Repeat("\n", rows), nil
+	case RenderQuarterBlock:
+		art = renderQuarterBlocks(img, cols, rows)
+	case RenderBraille:
+		art = renderBraille(img, cols, rows)
+	default:
+		art = renderHalfBlocks(img, cols, rows)
+	}
+	return adaptArt(art, colorProfile()), nil
+}
+
+// centerArt indents every line of art cols wide to center it in width.
+// Inline images bypass lipgloss, which would try to lay out their payload.
+func centerArt(art string, cols, width int) string {
+	if width <= cols {
+		return art
+	}
+	pad := strings.Repeat(" ", (width-cols)/2)
+
+	lines := strings.Split(art, "\n")
+	for i, line := range lines[:len(lines)-1] {
+		lines[i] = pad + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func decodeSprite(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// opaque treats pixels more than half covered as part of the sprite.
+func opaque(c color.NRGBA) bool {
+	return c.A >= 128
+}
+
+func fgSeq(c rgb) string {
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.r, c.g, c.b)
+}
+
+func bgSeq(c rgb) string {
+	return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c.r, c.g, c.b)
+}
+
+// meanColor averages the pixels selected by mask.
+func meanColor(px []color.NRGBA, mask int) rgb {
+	var r, g, b, n int
+	for i, c := range px {
+		if mask&(1<<i) != 0 {
+			r, g, b = r+int(c.R), g+int(c.G), b+int(c.B)
+			n++
+		}
+	}
+	if n == 0 {
+		return rgb{}
+	}
+	return rgb{r / n, g / n, b / n}
+}
+
+// renderHalfBlocks draws two pixels per cell with ▀ and ▄.
+func renderHalfBlocks(img image.Image, cols, rows int) string {
+	px := scaleImage(img, cols, rows*2)
+
+	var b strings.Builder
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			top, bottom := px.NRGBAAt(x, y*2), px.NRGBAAt(x, y*2+1)
+			topColor := rgb{int(top.R), int(top.G), int(top.B)}
+			bottomColor := rgb{int(bottom.R), int(bottom.G), int(bottom.B)}
+
+			switch {
+			case !opaque(top) && !opaque(bottom):
+				b.WriteByte(' ')
+			case !opaque(top):
+				b.WriteString(fgSeq(bottomColor) + "▄\x1b[0m")
+			case !opaque(bottom):
+				b.WriteString(fgSeq(topColor) + "▀\x1b[0m")
+			default:
+				b.WriteString(fgSeq(topColor) + bgSeq(bottomColor) + "▀\x1b[0m")
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// quadrants maps a 2×2 mask (bit 0 top-left, 1 top-right, 2 bottom-left,
+// 3 bottom-right) to its block character.
+var quadrants = []rune(" ▘▝▀▖▌▞▛▗▚▐▜▄▙▟█")
+
+// renderQuarterBlocks draws four pixels per cell. Each cell holds two
+// colors, so the pixels are split into the two groups that lose the least
+// detail.
+func renderQuarterBlocks(img image.Image, cols, rows int) string {
+	px := scaleImage(img, cols*2, rows*2)
+
+	var b strings.Builder
+	cell := make([]color.NRGBA, 4)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			cell[0], cell[1] = px.NRGBAAt(x*2, y*2), px.NRGBAAt(x*2+1, y*2)
+			cell[2], cell[3] = px.NRGBAAt(x*2, y*2+1), px.NRGBAAt(x*2+1, y*2+1)
+
+			visible := 0
+			for i, c := range cell {
+				if opaque(c) {
+					visible |= 1 << i
+				}
+			}
+
+			switch visible {
+			case 0:
+				b.WriteByte(' ')
+			case 0xf:
+				mask := splitCell(cell)
+				b.WriteString(fgSeq(meanColor(cell, mask)) + bgSeq(meanColor(cell, ^mask&0xf)) +
+					string(quadrants[mask]) + "\x1b[0m")
+			default:
+				// Transparent pixels take the terminal background
+				b.WriteString(fgSeq(meanColor(cell, visible)) + string(quadrants[visible]) + "\x1b[0m")
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// splitCell picks the foreground mask that best splits four pixels into
+// two colors.
+func splitCell(cell []color.NRGBA) int {
+	best, bestErr := 0xf, -1
+	// A mask and its complement give the same split, so the masks with the
+	// top-left pixel set cover every option.
+	for mask := 1; mask <= 0xf; mask += 2 {
+		fg, bg := meanColor(cell, mask), meanColor(cell, ^mask&0xf)
+		var err int
+		for i, c := range cell {
+			ref := bg
+			if mask&(1<<i) != 0 {
+				ref = fg
+			}
+			dr, dg, db := int(c.R)-ref.r, int(c.G)-ref.g, int(c.B)-ref.b
+			err += dr*dr + dg*dg + db*db
+		}
+		if bestErr < 0 || err < bestErr {
+			best, bestErr = mask, err
+		}
+	}
+	return best
+}
+
+// brailleDots maps the pixel at (x, y) of a 2×4 cell to its braille dot.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// renderBraille draws eight pixels per cell as braille dots, in the average
+// color of the cell.
+func renderBraille(img image.Image, cols, rows int) string {
+	px := scaleImage(img, cols*2, rows*4)
+
+	var b strings.Builder
+	cell := make([]color.NRGBA, 0, 8)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			cell = cell[:0]
+			var dots rune
+			for dy := 0; dy < 4; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					c := px.NRGBAAt(x*2+dx, y*4+dy)
+					if opaque(c) {
+						dots |= brailleDots[dy][dx]
+						cell = append(cell, c)
+					}
+				}
+			}
+
+			if dots == 0 {
+				b.WriteByte(' ')
+				continue
+			}
+			b.WriteString(fgSeq(meanColor(cell, 1<<len(cell)-1)) + string(0x2800+dots) + "\x1b[0m")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
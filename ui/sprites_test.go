@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestArtArea(t *testing.T) {
+	tests := []struct {
+		width, height, reserved int
+		cols, rows              int
+	}{
+		{80, 40, 20, 70, 19},
+		{80, 24, 30, 70, minArtRows},
+		{12, 40, 20, minArtCols, 19},
+		{0, 0, 20, 65, minArtRows},
+	}
+	for _, tt := range tests {
+		m := PokedexModel{width: tt.width, height: tt.height}
+		cols, rows := m.artArea(tt.reserved)
+		if cols != tt.cols || rows != tt.rows {
+			t.Errorf("%dx%d reserving %d: artArea = %dx%d, want %dx%d", tt.width, tt.height, tt.reserved, cols, rows, tt.cols, tt.rows)
+		}
+	}
+}
+
+func TestFitArt(t *testing.T) {
+	tests := []struct {
+		size             image.Point
+		maxCols, maxRows int
+		cols, rows       int
+	}{
+		{image.Pt(96, 96), 70, 19, 38, 19},   // limited by rows
+		{image.Pt(96, 96), 20, 40, 20, 10},   // limited by columns
+		{image.Pt(96, 48), 200, 100, 96, 24}, // limited by maxArtCols
+		{image.Pt(0, 0), 70, 19, 0, 0},
+	}
+	for _, tt := range tests {
+		cols, rows := fitArt(tt.size, tt.maxCols, tt.maxRows)
+		if cols != tt.cols || rows != tt.rows {
+			t.Errorf("fitArt(%v, %d, %d) = %dx%d, want %dx%d", tt.size, tt.maxCols, tt.maxRows, cols, rows, tt.cols, tt.rows)
+		}
+	}
+}
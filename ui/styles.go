@@ -1,45 +1,24 @@
 package ui
 
 import (
+	"charm-pokemon/models"
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
-var (
-	typeColors = map[string]lipgloss.Color{
-		"normal":   lipgloss.Color("248"),
-		"fogo":     lipgloss.Color("208"),
-		"água":     lipgloss.Color("27"),
-		"erva":     lipgloss.Color("82"),
-		"elétrico": lipgloss.Color("226"),
-		"gelo":     lipgloss.Color("45"),
-		"lutador":  lipgloss.Color("160"),
-		"veneno":   lipgloss.Color("153"),
-		"terra":    lipgloss.Color("172"),
-		"voador":   lipgloss.Color("163"),
-		"psíquico": lipgloss.Color("203"),
-		"inseto":   lipgloss.Color("166"),
-		"pedra":    lipgloss.Color("179"),
-		"fantasma": lipgloss.Color("111"),
-		"dragão":   lipgloss.Color("169"),
-		"sombrio":  lipgloss.Color("88"),
-		"metálico": lipgloss.Color("201"),
-		"fada":     lipgloss.Color("197"),
-	}
-)
-
 func getTypeColor(typeName string) lipgloss.Color {
-	if color, ok := typeColors[typeName]; ok {
+	if color, ok := theme.TypeColors[typeName]; ok {
 		return color
 	}
-	return lipgloss.Color("255")
+	return theme.Text
 }
 
 func getTitleStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Primary).
 		Align(lipgloss.Center).
 		MarginTop(1).
 		MarginBottom(1)
@@ -48,35 +27,41 @@ func getTitleStyle() lipgloss.Style {
 func getHeaderStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("226")).
+		Foreground(theme.Accent).
 		MarginBottom(1)
 }
 
 func getLabelStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Primary).
+		Bold(true)
+}
+
+func getWarningStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(theme.Accent).
 		Bold(true)
 }
 
 func getValueStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("255"))
+		Foreground(theme.Text)
 }
 
 func getCursorStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("226")).
+		Foreground(theme.Accent).
 		Bold(true)
 }
 
 func getNormalItemStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("255"))
+		Foreground(theme.Text)
 }
 
 func getSelectedStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Primary).
 		Bold(true)
 }
 
@@ -89,21 +74,28 @@ func getTypeStyle(typeName string) lipgloss.Style {
 func getHighlightStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("226")).
-		Background(lipgloss.Color("88"))
+		Foreground(theme.Accent).
+		Background(theme.Highlight)
 }
 
 func getBoxStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("39")).
+		BorderForeground(theme.Primary).
 		Padding(1, 2)
 }
 
+// The parts of a rendered stat bar: the bar, a gap, then the value.
+const (
+	statBarWidth   = 15
+	statBarGap     = 4
+	statValueWidth = 3
+)
+
 func getStatBarStyle(stat int, maxValue int) string {
-	width := 15
+	width := statBarWidth
 	if maxValue <= 0 {
-		maxValue = 150 // Default max for Pokemon stats
+		maxValue = models.MaxBaseStat
 	}
 	filled := int(float64(stat) / float64(maxValue) * float64(width))
 	if filled > width {
@@ -125,8 +117,28 @@ func renderStatBar(stat int, maxValue int) string {
 	bar := getStatBarStyle(stat, maxValue)
 	return lipgloss.JoinHorizontal(lipgloss.Left,
 		bar,
-		lipgloss.NewStyle().Width(4).Render(""),
-		lipgloss.NewStyle().Render(fmt.Sprintf("%3d", stat)),
+		lipgloss.NewStyle().Width(statBarGap).Render(""),
+		lipgloss.NewStyle().Render(fmt.Sprintf("%*d", statValueWidth, stat)),
+	)
+}
+
+// renderRankedStatBar is renderStatBar with the filled part colored by the
+// percentile of stat, along the theme's stat scale.
+func renderRankedStatBar(stat int, maxValue int, percentile float64) string {
+	bar := getStatBarStyle(stat, maxValue)
+	filled := strings.TrimRight(bar, "░")
+	empty := bar[len(filled):]
+	if scale := theme.StatScale; len(scale) > 0 {
+		step := int(percentile / 100 * float64(len(scale)))
+		if step >= len(scale) {
+			step = len(scale) - 1
+		}
+		filled = lipgloss.NewStyle().Foreground(scale[step]).Render(filled)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Left,
+		filled+empty,
+		lipgloss.NewStyle().Width(statBarGap).Render(""),
+		lipgloss.NewStyle().Render(fmt.Sprintf("%*d", statValueWidth, stat)),
 	)
 }
 
@@ -165,6 +177,8 @@ const (
 	LabelBROWSE_GEN      = "📚 Gerações"
 	LabelFAVORITES       = "⭐ Favoritos"
 	LabelDETAILS         = "📊 Detalhes"
+	LabelDETAILS_HINT    = "Enter para detalhes"
+	LabelCLEAR_FILTERS   = "Limpar Filtros"
 	LabelPREV            = "◀ Anterior"
 	LabelNEXT            = "Próximo ▶"
 	LabelBACK            = "◀ Voltar"
@@ -183,13 +197,169 @@ const (
 	LabelNORMAL          = "Normal"
 	LabelNO_RESULTS      = "Nenhum resultado encontrado"
 	LabelNO_FAVORITES    = "Nenhum favorito ainda"
-	LabelPRESS_ENTER     = "Pressiona Enter para selecionar"
-	LabelPRESS_Q         = "Pressiona q para voltar"
 	LabelTOGGLE_FAVORITE = "⭐ Favorito"
+	LabelFORM            = "Forma:"
+	LabelDEFAULT_FORM    = "Base"
 	LabelGENERATIONS     = "Navegar por Geração"
 	LabelTYPES           = "Navegar por Tipo"
+	LabelBROWSE_ABILITY  = "💡 Habilidades"
+	LabelABILITIES       = "Habilidades:"
+	LabelABILITIES_ALL   = "Navegar por Habilidade"
+	LabelABILITIES_OF    = "Habilidades de %s"
+	LabelABILITY         = "Habilidade: %s"
+	LabelHIDDEN_ABILITY  = "(oculta)"
+	LabelNO_ABILITIES    = "Sem dados de habilidades"
+	LabelLEARNSET_OF     = "Movimentos de %s"
+	LabelVERSION         = "Versão:"
+	LabelNO_LEARNSET     = "Sem dados de movimentos"
+	LabelEVOLVE_LEVEL    = "Evol."
+	LabelLINES           = "Linhas %d-%d de %d"
+	LabelBROWSE_MOVES    = "🥊 Movimentos"
+	LabelMOVES_ALL       = "Navegar por Movimento"
+	LabelMOVE            = "Movimento: %s"
+	LabelMOVE_COLUMN     = "Movimento"
+	LabelCATEGORY        = "Categoria:"
+	LabelALL_TYPES       = "todos"
+	LabelALL_CATEGORIES  = "todas"
+	LabelNO_MOVES        = "Nenhum movimento nos dados"
+	LabelLEARNED_BY      = "Aprendido por %d Pokémon · %d movimentos na lista"
+	LabelBROWSE_EGGS     = "🥚 Grupos de Ovos"
+	LabelEGG_GROUPS_ALL  = "Navegar por Grupo de Ovos"
+	LabelEGG_GROUPS_OF   = "Grupos de ovos de %s"
+	LabelEGG_GROUP       = "Grupo de ovos: %s"
+	LabelNO_EGGS         = "Estes Pokémon não se reproduzem"
+	LabelNO_BREEDING     = "Sem dados de criação"
+	LabelPAGE            = "Página %d/%d"
+	LabelBREEDING        = "Criação:"
+	LabelTRAINING        = "Treino:"
+	LabelSPECIES         = "Espécie:"
+	LabelEGG_GROUPS      = "Grupos de ovos"
+	LabelGENDER          = "Género"
+	LabelGENDERLESS      = "sem género"
+	LabelHATCH           = "Eclosão"
+	LabelHATCH_CYCLES    = "%d ciclos (~%d passos)"
+	LabelEV_YIELD        = "EVs ganhos"
+	LabelBASE_EXPERIENCE = "Experiência base"
+	LabelCAPTURE_RATE    = "Taxa de captura"
+	LabelBASE_HAPPINESS  = "Amizade base"
+	LabelGROWTH_RATE     = "Crescimento"
+	LabelHABITAT         = "Habitat"
+	LabelCOLOR           = "Cor"
+	LabelBROWSE_REGIONS  = "🗺️ Regiões"
+	LabelREGIONS_ALL     = "Navegar por Pokédex Regional"
+	LabelREGIONAL_DEX    = "Pokédex de %s"
+	LabelNO_REGIONS      = "Sem Pokédex regionais nos dados"
+	LabelCALCULATOR_OF   = "Calculadora: %s"
+	LabelLEVEL           = "Nível"
+	LabelNATURE          = "Natureza"
+	LabelNEUTRAL         = "neutra"
+	LabelEVS_USED        = "EVs"
+	LabelTEAM            = "👥 Equipa"
+	LabelTEAM_OF         = "Equipa (%d/%d)"
+	LabelTEAM_EMPTY      = "Equipa vazia: junta Pokémon com [%s] no detalhe ou importa de Showdown com [%s]"
+	LabelTEAM_ADDED      = "%s juntou-se à equipa"
+	LabelTEAM_IMPORTED   = "%d Pokémon importados da área de transferência"
+	LabelTEAM_EXPORTED   = "Equipa copiada para a área de transferência no formato Showdown"
+	LabelTEAM_PROBLEMS   = "%d linhas ignoradas:"
+	LabelLINE            = "Linha"
+	LabelCLIPBOARD_ERROR = "Área de transferência indisponível: %v"
+	LabelRANK_ALL        = "Todos"
+	LabelRANK_GENERATION = "Ger. %d"
+	LabelRANK_HINT       = "#posição entre os Pokémon de cada coluna · percentil, com 50% na mediana"
+	LabelCALC_HINT       = "Nv. 50 e Nv. 100: do pior caso (0 IVs, 0 EVs, natureza contra) ao melhor (31 IVs, 252 EVs, natureza a favor)"
+
+	LabelRANDOM_TEAM_TITLE  = "Equipa aleatória"
+	LabelRANDOM_CONSTRAINTS = "Restrições"
+	LabelRANDOM_GENERATION  = "Geração"
+	LabelRANDOM_TYPE        = "Tipo"
+	LabelRANDOM_UNIQUE      = "Sem tipos repetidos"
+	LabelRANDOM_MIN_BST     = "Total mínimo"
+	LabelRANDOM_MAX_BST     = "Total máximo"
+	LabelRANDOM_EVOLVED     = "Só evoluções finais"
+	LabelRANDOM_LEGENDARY   = "Sem lendários"
+	LabelRANDOM_FAVORITES   = "Só favoritos"
+	LabelUNAVAILABLE        = "indisponível"
+	LabelRANDOM_TEAM        = "Equipa (semente %d, %d candidatos)"
+	LabelRANDOM_SAVED       = "Equipa aleatória guardada"
+	LabelANY                = "qualquer"
+	LabelYES                = "sim"
+	LabelNO                 = "não"
+
+	LabelMODE_UNAVAILABLE = "⚠ Modo %s indisponível: %v"
 )
 
+// statNames are the short names of the stats.
+var statNames = map[models.Stat]string{
+	models.StatHP:      "HP",
+	models.StatAttack:  "Ataque",
+	models.StatDefense: "Defesa",
+	models.StatSpAtk:   "Sp.Atk",
+	models.StatSpDef:   "Sp.Def",
+	models.StatSpeed:   "Veloc.",
+	models.StatTotal:   "Total",
+}
+
+// moveCategoryNames labels the damage classes of moves.
+var moveCategoryNames = map[string]string{
+	"physical": "Físico",
+	"special":  "Especial",
+	"status":   "Estado",
+}
+
+// growthRateNames labels the experience curves.
+var growthRateNames = map[string]string{
+	"slow":                "Lento",
+	"medium":              "Médio",
+	"fast":                "Rápido",
+	"medium-slow":         "Médio-lento",
+	"slow-then-very-fast": "Errático",
+	"fast-then-very-slow": "Flutuante",
+}
+
+// habitatNames labels the habitats of the first Pokedex games.
+var habitatNames = map[string]string{
+	"cave":          "Caverna",
+	"forest":        "Floresta",
+	"grassland":     "Pradaria",
+	"mountain":      "Montanha",
+	"rare":          "Raro",
+	"rough-terrain": "Terreno acidentado",
+	"sea":           "Mar",
+	"urban":         "Urbano",
+	"waters-edge":   "Beira da água",
+}
+
+// colorNames labels the Pokedex colors.
+var colorNames = map[string]string{
+	"black":  "Preto",
+	"blue":   "Azul",
+	"brown":  "Castanho",
+	"gray":   "Cinzento",
+	"green":  "Verde",
+	"pink":   "Rosa",
+	"purple": "Roxo",
+	"red":    "Vermelho",
+	"white":  "Branco",
+	"yellow": "Amarelo",
+}
+
+// learnMethodNames heads the groups of the learnset screen.
+var learnMethodNames = map[models.LearnMethod]string{
+	models.LearnLevelUp: "Por nível:",
+	models.LearnMachine: "MT/DT:",
+	models.LearnEgg:     "Ovo:",
+	models.LearnTutor:   "Tutor:",
+}
+
+// formKindNames labels the kinds of alternate forms.
+var formKindNames = map[models.FormKind]string{
+	models.FormOther:      "Alternativa",
+	models.FormRegional:   "Regional",
+	models.FormMega:       "Mega",
+	models.FormGigantamax: "Gigantamax",
+	models.FormCosmetic:   "Cosmética",
+}
+
 var TypeNames = []string{
 	"normal",
 	"fogo",
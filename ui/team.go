@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"charm-pokemon/models"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *PokedexModel) openTeam() {
+	m.state = StateTeam
+	m.teamCursor = 0
+	m.teamNotice = ""
+}
+
+func (m PokedexModel) updateTeam(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = StatePokedexView
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Select):
+		m.selectTeamMember(m.teamCursor)
+
+	case key.Matches(msg, m.keys.ImportTeam):
+		m.importTeam()
+
+	case key.Matches(msg, m.keys.ExportTeam):
+		m.exportTeam()
+
+	case key.Matches(msg, m.keys.RandomTeam):
+		m.openRandomTeam()
+
+	case key.Matches(msg, m.keys.RemoveMember):
+		if err := m.team.Remove(m.teamCursor); err != nil {
+			m.teamNotice = err.Error()
+		}
+		if m.teamCursor >= len(m.team.Members) && m.teamCursor > 0 {
+			m.teamCursor--
+		}
+	}
+	return m, nil
+}
+
+// selectTeamMember opens the detail view of the member at index, in its
+// form.
+func (m *PokedexModel) selectTeamMember(index int) {
+	if m.team == nil || index < 0 || index >= len(m.team.Members) {
+		return
+	}
+	member := m.team.Members[index].Pokemon
+	species := m.pokedex.GetByID(member.ID)
+	if species == nil {
+		return
+	}
+	m.currentPokemon = species
+	m.currentPokemon.IsFavorite = m.favorites.IsFavorite(species.ID)
+	m.formOf, m.formIndex = species.ID, 0
+	if member.Form != nil {
+		for i, form := range species.Forms {
+			if form.ID == member.Form.ID {
+				m.formIndex = i + 1
+			}
+		}
+	}
+	m.state = StateDetail
+}
+
+// importTeam replaces the team with the one on the clipboard.
+func (m *PokedexModel) importTeam() {
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		m.teamNotice = fmt.Sprintf(LabelCLIPBOARD_ERROR, err)
+		return
+	}
+	m.teamCursor = 0
+	count, err := m.team.Import(text)
+	m.teamNotice = fmt.Sprintf(LabelTEAM_IMPORTED, count)
+	var problems *models.ShowdownError
+	if err != nil && !errors.As(err, &problems) {
+		m.teamNotice = err.Error()
+	}
+}
+
+// exportTeam copies the team to the clipboard in Showdown's format.
+func (m *PokedexModel) exportTeam() {
+	if err := clipboard.WriteAll(m.team.Export()); err != nil {
+		m.teamNotice = fmt.Sprintf(LabelCLIPBOARD_ERROR, err)
+		return
+	}
+	m.teamNotice = LabelTEAM_EXPORTED
+}
+
+// addToTeam adds the Pokemon of the detail view, in its form, to the team
+// and shows the team.
+func (m *PokedexModel) addToTeam() {
+	pokemon := m.shownPokemon()
+	if pokemon == nil || m.team == nil {
+		return
+	}
+	err := m.team.Add(pokemon)
+	m.openTeam()
+	m.teamCursor = len(m.team.Members) - 1
+	m.teamNotice = fmt.Sprintf(LabelTEAM_ADDED, pokemon.NamePT)
+	if err != nil {
+		m.teamNotice = err.Error()
+	}
+}
+
+func (m PokedexModel) viewTeam() string {
+	var s strings.Builder
+
+	s.WriteString(m.listHeader())
+
+	faint := lipgloss.NewStyle().Faint(true)
+	if len(m.team.Members) == 0 {
+		s.WriteString(faint.Render(fmt.Sprintf(LabelTEAM_EMPTY, m.keys.AddToTeam.Help().Key, m.keys.ImportTeam.Help().Key)))
+		s.WriteString("\n")
+	}
+
+	for i, member := range m.team.Members {
+		cursor := " "
+		style := getNormalItemStyle()
+		if i == m.teamCursor {
+			cursor = ">"
+			style = getCursorStyle()
+		}
+
+		name := member.Pokemon.NamePT
+		if member.Nickname != "" {
+			name = fmt.Sprintf("%s (%s)", member.Nickname, name)
+		}
+		typeEmojis := ""
+		for _, t := range member.Pokemon.Types {
+			typeEmojis += getTypeEmoji(t) + " "
+		}
+		line := fmt.Sprintf("%s %-28s %s %s %d", cursor, name, typeEmojis, LabelLEVEL, member.Spread.Level)
+		if member.Item != "" {
+			line += " @ " + member.Item
+		}
+		s.WriteString(style.Render(line))
+		s.WriteString("\n")
+
+		details := []string{member.Spread.Nature.NamePT}
+		if member.Ability != "" {
+			details = append([]string{m.pokedex.AbilityName(member.Ability)}, details...)
+		}
+		moves := make([]string, len(member.Moves))
+		for j, move := range member.Moves {
+			moves[j] = m.pokedex.MoveName(move)
+		}
+		if len(moves) > 0 {
+			details = append(details, strings.Join(moves, ", "))
+		}
+		s.WriteString(faint.Render("    " + strings.Join(details, " · ")))
+		s.WriteString("\n")
+	}
+
+	if m.teamNotice != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(theme.Accent).Render(m.teamNotice))
+		s.WriteString("\n")
+	}
+
+	var problems *models.ShowdownError
+	if errors.As(m.team.Problems, &problems) {
+		s.WriteString("\n")
+		s.WriteString(getLabelStyle().Render(fmt.Sprintf(LabelTEAM_PROBLEMS, len(problems.Lines))))
+		s.WriteString("\n")
+		for _, line := range problems.Lines {
+			s.WriteString(fmt.Sprintf("  %s %d: %v\n", LabelLINE, line.Line, line.Err))
+			s.WriteString(faint.Render("      " + line.Text))
+			s.WriteString("\n")
+		}
+	} else if m.team.Problems != nil {
+		s.WriteString("\n")
+		s.WriteString(m.team.Problems.Error())
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/cancelreader"
+)
+
+// kittyQuery asks for a tiny test image without displaying it; terminals
+// that implement the Kitty protocol answer with "OK".
+const kittyQuery = "\x1b_Gi=31,s=1,v=1,a=q,t=d,f=24;AAAA\x1b\\"
+
+// cellSizeQuery asks for the size of a character cell in pixels
+// (XTWINOPS 16), answered with "ESC [ 6 ; height ; width t".
+const cellSizeQuery = "\x1b[16t"
+
+// deviceAttributes (DA1) is answered by virtually every terminal, so its
+// reply marks the end of the query. Sixel support shows up as feature 4.
+const deviceAttributes = "\x1b[c"
+
+// queryGraphics asks the terminal which image protocols it supports. It
+// reports false when there is no terminal or no answer within timeout.
+func queryGraphics(timeout time.Duration) (GraphicsSupport, bool) {
+	in, out := os.Stdin, os.Stdout
+	if !term.IsTerminal(in.Fd()) || !term.IsTerminal(out.Fd()) {
+		return GraphicsSupport{}, false
+	}
+
+	state, err := term.MakeRaw(in.Fd())
+	if err != nil {
+		return GraphicsSupport{}, false
+	}
+	defer term.Restore(in.Fd(), state)
+
+	reader, err := cancelreader.NewReader(in)
+	if err != nil {
+		return GraphicsSupport{}, false
+	}
+	defer reader.Close()
+
+	if _, err := io.WriteString(out, kittyQuery+cellSizeQuery+deviceAttributes); err != nil {
+		return GraphicsSupport{}, false
+	}
+
+	timer := time.AfterFunc(timeout, func() { reader.Cancel() })
+	defer timer.Stop()
+
+	var reply strings.Builder
+	buf := make([]byte, 256)
+	for {
+		n, err := reader.Read(buf)
+		reply.Write(buf[:n])
+		if attrs, ok := deviceAttributesReply(reply.String()); ok {
+			g := GraphicsSupport{
+				Kitty: strings.Contains(reply.String(), "\x1b_Gi=31;OK"),
+				Sixel: hasFeature(attrs, "4"),
+			}
+			g.CellWidth, g.CellHeight = cellSizeReply(reply.String())
+			return g, true
+		}
+		if err != nil {
+			return GraphicsSupport{}, false
+		}
+	}
+}
+
+// deviceAttributesReply extracts the parameters of a DA1 reply
+// ("ESC [ ? 62 ; 4 ; 22 c").
+func deviceAttributesReply(s string) (string, bool) {
+	start := strings.Index(s, "\x1b[?")
+	if start < 0 {
+		return "", false
+	}
+	params := s[start+3:]
+	end := strings.IndexByte(params, 'c')
+	if end < 0 {
+		return "", false
+	}
+	return params[:end], true
+}
+
+func hasFeature(params, feature string) bool {
+	for _, p := range strings.Split(params, ";") {
+		if p == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// cellSizeReply extracts the cell size from an XTWINOPS 16 reply, or
+// returns zeros when the terminal did not send one.
+func cellSizeReply(s string) (int, int) {
+	start := strings.Index(s, "\x1b[6;")
+	if start < 0 {
+		return 0, 0
+	}
+	params := s[start+4:]
+	end := strings.IndexByte(params, 't')
+	if end < 0 {
+		return 0, 0
+	}
+
+	var height, width int
+	if _, err := fmt.Sscanf(params[:end], "%d;%d", &height, &width); err != nil {
+		return 0, 0
+	}
+	return width, height
+}
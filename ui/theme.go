@@ -0,0 +1,291 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds every color used by the interface.
+type Theme struct {
+	Name string
+
+	Primary   lipgloss.Color // titles, labels, borders and the selected menu entry
+	Accent    lipgloss.Color // cursor, headers, shiny and favorite markers
+	Text      lipgloss.Color // regular text and list entries
+	Highlight lipgloss.Color // background behind highlighted text
+	Pikachu   lipgloss.Color // the welcome Pikachu
+
+	StatScale []lipgloss.Color // stat bars, from the lowest percentile to the highest
+
+	TypeColors map[string]lipgloss.Color
+}
+
+// theme is the active theme, read by all the style helpers.
+var theme = DarkTheme()
+
+// CurrentTheme returns the active theme.
+func CurrentTheme() Theme {
+	return theme
+}
+
+// SetTheme makes t the active theme.
+func SetTheme(t Theme) {
+	theme = t
+}
+
+func DarkTheme() Theme {
+	return Theme{
+		Name:      "dark",
+		Primary:   lipgloss.Color("39"),
+		Accent:    lipgloss.Color("226"),
+		Text:      lipgloss.Color("255"),
+		Highlight: lipgloss.Color("88"),
+		Pikachu:   lipgloss.Color("226"),
+		StatScale: []lipgloss.Color{"196", "208", "226", "82", "45"},
+		TypeColors: map[string]lipgloss.Color{
+			"normal":   lipgloss.Color("248"),
+			"fogo":     lipgloss.Color("208"),
+			"água":     lipgloss.Color("27"),
+			"erva":     lipgloss.Color("82"),
+			"elétrico": lipgloss.Color("226"),
+			"gelo":     lipgloss.Color("45"),
+			"lutador":  lipgloss.Color("160"),
+			"veneno":   lipgloss.Color("153"),
+			"terra":    lipgloss.Color("172"),
+			"voador":   lipgloss.Color("163"),
+			"psíquico": lipgloss.Color("203"),
+			"inseto":   lipgloss.Color("166"),
+			"pedra":    lipgloss.Color("179"),
+			"fantasma": lipgloss.Color("111"),
+			"dragão":   lipgloss.Color("169"),
+			"sombrio":  lipgloss.Color("88"),
+			"metálico": lipgloss.Color("201"),
+			"fada":     lipgloss.Color("197"),
+		},
+	}
+}
+
+func LightTheme() Theme {
+	return Theme{
+		Name:      "light",
+		Primary:   lipgloss.Color("25"),
+		Accent:    lipgloss.Color("130"),
+		Text:      lipgloss.Color("235"),
+		Highlight: lipgloss.Color("223"),
+		Pikachu:   lipgloss.Color("178"),
+		StatScale: []lipgloss.Color{"160", "166", "136", "28", "25"},
+		TypeColors: map[string]lipgloss.Color{
+			"normal":   lipgloss.Color("243"),
+			"fogo":     lipgloss.Color("166"),
+			"água":     lipgloss.Color("26"),
+			"erva":     lipgloss.Color("28"),
+			"elétrico": lipgloss.Color("136"),
+			"gelo":     lipgloss.Color("31"),
+			"lutador":  lipgloss.Color("124"),
+			"veneno":   lipgloss.Color("91"),
+			"terra":    lipgloss.Color("94"),
+			"voador":   lipgloss.Color("61"),
+			"psíquico": lipgloss.Color("162"),
+			"inseto":   lipgloss.Color("64"),
+			"pedra":    lipgloss.Color("101"),
+			"fantasma": lipgloss.Color("54"),
+			"dragão":   lipgloss.Color("56"),
+			"sombrio":  lipgloss.Color("52"),
+			"metálico": lipgloss.Color("66"),
+			"fada":     lipgloss.Color("169"),
+		},
+	}
+}
+
+// HighContrastTheme sticks to the bright basic colors, which every terminal
+// renders at full intensity.
+func HighContrastTheme() Theme {
+	return Theme{
+		Name:      "high-contrast",
+		Primary:   lipgloss.Color("14"),
+		Accent:    lipgloss.Color("11"),
+		Text:      lipgloss.Color("15"),
+		Highlight: lipgloss.Color("4"),
+		Pikachu:   lipgloss.Color("11"),
+		StatScale: []lipgloss.Color{"9", "11", "15", "10", "14"},
+		TypeColors: map[string]lipgloss.Color{
+			"normal":   lipgloss.Color("15"),
+			"fogo":     lipgloss.Color("9"),
+			"água":     lipgloss.Color("12"),
+			"erva":     lipgloss.Color("10"),
+			"elétrico": lipgloss.Color("11"),
+			"gelo":     lipgloss.Color("14"),
+			"lutador":  lipgloss.Color("9"),
+			"veneno":   lipgloss.Color("13"),
+			"terra":    lipgloss.Color("11"),
+			"voador":   lipgloss.Color("14"),
+			"psíquico": lipgloss.Color("13"),
+			"inseto":   lipgloss.Color("10"),
+			"pedra":    lipgloss.Color("11"),
+			"fantasma": lipgloss.Color("13"),
+			"dragão":   lipgloss.Color("12"),
+			"sombrio":  lipgloss.Color("15"),
+			"metálico": lipgloss.Color("15"),
+			"fada":     lipgloss.Color("13"),
+		},
+	}
+}
+
+// ColorblindTheme uses the Okabe-Ito palette, which stays distinguishable
+// for the common forms of color blindness.
+func ColorblindTheme() Theme {
+	const (
+		orange    = lipgloss.Color("#E69F00")
+		skyBlue   = lipgloss.Color("#56B4E9")
+		green     = lipgloss.Color("#009E73")
+		yellow    = lipgloss.Color("#F0E442")
+		blue      = lipgloss.Color("#0072B2")
+		vermilion = lipgloss.Color("#D55E00")
+		purple    = lipgloss.Color("#CC79A7")
+		grey      = lipgloss.Color("#BBBBBB")
+	)
+
+	return Theme{
+		Name:      "colorblind",
+		Primary:   skyBlue,
+		Accent:    yellow,
+		Text:      lipgloss.Color("#FFFFFF"),
+		Highlight: blue,
+		Pikachu:   yellow,
+		StatScale: []lipgloss.Color{vermilion, orange, yellow, skyBlue, blue},
+		TypeColors: map[string]lipgloss.Color{
+			"normal":   grey,
+			"fogo":     vermilion,
+			"água":     blue,
+			"erva":     green,
+			"elétrico": yellow,
+			"gelo":     skyBlue,
+			"lutador":  vermilion,
+			"veneno":   purple,
+			"terra":    orange,
+			"voador":   skyBlue,
+			"psíquico": purple,
+			"inseto":   green,
+			"pedra":    orange,
+			"fantasma": purple,
+			"dragão":   blue,
+			"sombrio":  grey,
+			"metálico": grey,
+			"fada":     purple,
+		},
+	}
+}
+
+// Themes lists the built-in themes by name.
+func Themes() map[string]Theme {
+	themes := make(map[string]Theme)
+	for _, t := range []Theme{DarkTheme(), LightTheme(), HighContrastTheme(), ColorblindTheme()} {
+		themes[t.Name] = t
+	}
+	return themes
+}
+
+// themeFile is the JSON layout of a user theme. Colors are ANSI numbers
+// ("39") or hex values ("#56B4E9"); anything left out comes from the base
+// theme.
+type themeFile struct {
+	Name      string            `json:"name"`
+	Base      string            `json:"base"`
+	Primary   string            `json:"primary"`
+	Accent    string            `json:"accent"`
+	Text      string            `json:"text"`
+	Highlight string            `json:"highlight"`
+	Pikachu   string            `json:"pikachu"`
+	StatScale []string          `json:"stat_scale"`
+	Types     map[string]string `json:"types"`
+}
+
+// LoadTheme resolves a theme by name: "auto" picks dark or light from the
+// terminal background, a built-in name selects that theme, and anything else
+// is read as a path to a JSON theme file.
+func LoadTheme(name string) (Theme, error) {
+	themes := Themes()
+
+	if name == "" || name == "auto" {
+		if lipgloss.HasDarkBackground() {
+			return themes["dark"], nil
+		}
+		return themes["light"], nil
+	}
+
+	if t, ok := themes[name]; ok {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DarkTheme(), fmt.Errorf("tema desconhecido %q (disponíveis: %s)", name, strings.Join(themeNames(themes), ", "))
+		}
+		return DarkTheme(), err
+	}
+
+	var file themeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return DarkTheme(), fmt.Errorf("%s: %w", name, err)
+	}
+
+	t := DarkTheme()
+	if file.Base != "" {
+		base, ok := themes[file.Base]
+		if !ok {
+			return DarkTheme(), fmt.Errorf("%s: tema base desconhecido %q", name, file.Base)
+		}
+		t = base
+	}
+
+	t.Name = name
+	if file.Name != "" {
+		t.Name = file.Name
+	}
+	overrideColor(&t.Primary, file.Primary)
+	overrideColor(&t.Accent, file.Accent)
+	overrideColor(&t.Text, file.Text)
+	overrideColor(&t.Highlight, file.Highlight)
+	overrideColor(&t.Pikachu, file.Pikachu)
+	if len(file.StatScale) > 0 {
+		t.StatScale = make([]lipgloss.Color, len(file.StatScale))
+		for i, color := range file.StatScale {
+			t.StatScale[i] = lipgloss.Color(color)
+		}
+	}
+
+	typeColors := make(map[string]lipgloss.Color, len(t.TypeColors))
+	for typeName, color := range t.TypeColors {
+		typeColors[typeName] = color
+	}
+	for typeName, color := range file.Types {
+		if _, ok := typeColors[typeName]; !ok {
+			return DarkTheme(), fmt.Errorf("%s: tipo desconhecido %q", name, typeName)
+		}
+		typeColors[typeName] = lipgloss.Color(color)
+	}
+	t.TypeColors = typeColors
+
+	return t, nil
+}
+
+func overrideColor(dst *lipgloss.Color, value string) {
+	if value != "" {
+		*dst = lipgloss.Color(value)
+	}
+}
+
+func themeNames(themes map[string]Theme) []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}